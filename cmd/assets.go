@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/narasux/goblog/pkg/assets"
+	"github.com/narasux/goblog/pkg/logging"
+	"github.com/narasux/goblog/pkg/utils/compressx"
+	"github.com/narasux/goblog/pkg/utils/markdownx"
+)
+
+// 代码高亮主题 css 文件（相对静态文件目录）
+const highlightCSSFile = "css/chroma.css"
+
+// 需要预压缩的静态文件类型（图片、woff 字体等本身已压缩的文件无需处理）
+var precompressibleExts = []string{
+	".js", ".css", ".html", ".svg", ".json", ".xml", ".txt", ".map", ".ttf", ".eot", ".otf", ".ico",
+}
+
+var assetsCmd = &cobra.Command{
+	Use:   "assets",
+	Short: "Manage static assets.",
+}
+
+// NewAssetsCompressCmd ...
+func NewAssetsCompressCmd() *cobra.Command {
+	var force bool
+	var minSize int64
+
+	compressCmd := cobra.Command{
+		Use:   "compress",
+		Short: "Generate precompressed .br and .gz siblings for static assets.",
+		Run: func(cmd *cobra.Command, args []string) {
+			logging.InitLogger()
+			logger := logging.GetSystemLogger()
+
+			// 预压缩文件需要写入磁盘（源码目录或 STATIC_FILE_BASE_DIR），构建二进制时会一并内嵌
+			staticDir := assets.DiskDir(assets.StaticDir)
+			err := filepath.WalkDir(staticDir, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.IsDir() || !isPrecompressible(path) {
+					return nil
+				}
+				info, err := d.Info()
+				if err != nil {
+					return err
+				}
+				// 过小的文件压缩收益不大
+				if info.Size() < minSize {
+					return nil
+				}
+
+				for _, encoding := range compressx.Encodings {
+					generated, err := precompress(path, info, encoding, force)
+					if err != nil {
+						return err
+					}
+					if generated {
+						logger.Infof("compressed %s -> %s", path, path+compressx.Extension(encoding))
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				logger.Fatalf("failed to compress static assets: %s", err)
+			}
+			logger.Infof("static assets under %s compressed", staticDir)
+		},
+	}
+
+	compressCmd.Flags().BoolVar(&force, "force", false, "regenerate even if the compressed file is up to date")
+	compressCmd.Flags().Int64Var(&minSize, "min-size", 1024, "skip files smaller than this size (bytes)")
+
+	return &compressCmd
+}
+
+// NewAssetsHighlightCSSCmd ...
+func NewAssetsHighlightCSSCmd() *cobra.Command {
+	var style string
+
+	highlightCSSCmd := cobra.Command{
+		Use:   "highlight-css",
+		Short: "Generate the css theme for server-side code highlighting.",
+		Run: func(cmd *cobra.Command, args []string) {
+			logging.InitLogger()
+			logger := logging.GetSystemLogger()
+
+			var buf bytes.Buffer
+			if err := markdownx.WriteHighlightCSS(&buf, style); err != nil {
+				logger.Fatalf("failed to generate highlight css: %s", err)
+			}
+			// 写入磁盘（源码目录或 STATIC_FILE_BASE_DIR），构建二进制时会一并内嵌
+			target := filepath.Join(assets.DiskDir(assets.StaticDir), highlightCSSFile)
+			if err := os.WriteFile(target, buf.Bytes(), 0o644); err != nil {
+				logger.Fatalf("failed to write highlight css: %s", err)
+			}
+			logger.Infof("highlight css (style: %s) generated to %s", style, target)
+		},
+	}
+
+	highlightCSSCmd.Flags().StringVar(&style, "style", "monokai", "chroma style name, e.g. monokai, github, dracula")
+
+	return &highlightCSSCmd
+}
+
+func isPrecompressible(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range precompressibleExts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// precompress 生成指定编码的预压缩文件，若已存在且不比原文件旧则跳过（除非 force），
+// 压缩后体积没有变小的文件也会跳过（并清理旧的预压缩文件），返回是否生成了新文件
+func precompress(path string, info fs.FileInfo, encoding string, force bool) (bool, error) {
+	target := path + compressx.Extension(encoding)
+	if targetInfo, err := os.Stat(target); err == nil && !force && !targetInfo.ModTime().Before(info.ModTime()) {
+		return false, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	var buf bytes.Buffer
+	writer := compressx.NewWriter(encoding, &buf, true)
+	if _, err = writer.Write(content); err != nil {
+		return false, err
+	}
+	if err = writer.Close(); err != nil {
+		return false, err
+	}
+
+	if int64(buf.Len()) >= info.Size() {
+		_ = os.Remove(target)
+		return false, nil
+	}
+	return true, os.WriteFile(target, buf.Bytes(), 0o644)
+}
+
+func init() {
+	assetsCmd.AddCommand(NewAssetsCompressCmd())
+	assetsCmd.AddCommand(NewAssetsHighlightCSSCmd())
+	rootCmd.AddCommand(assetsCmd)
+}
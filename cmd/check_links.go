@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/narasux/goblog/pkg/assets"
+	"github.com/narasux/goblog/pkg/linkcheck"
+	"github.com/narasux/goblog/pkg/logging"
+)
+
+// NewCheckLinksCmd ...
+func NewCheckLinksCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check-links",
+		Short: "Report malformed external links in articles (offline, no requests are sent).",
+		Long: "Report malformed external links in articles (offline, no requests are sent). " +
+			"Internal links are checked when articles are loaded.",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logging.GetSystemLogger()
+
+			files, err := fs.Glob(assets.Data(), "articles/*.md")
+			if err != nil {
+				logger.Fatalf("failed to list articles: %s", err)
+			}
+
+			var problems []linkcheck.Problem
+			for _, file := range files {
+				content, err := fs.ReadFile(assets.Data(), file)
+				if err != nil {
+					logger.Fatalf("failed to read %s: %s", file, err)
+				}
+				problems = append(problems, linkcheck.CheckMarkdown(file, content)...)
+			}
+			if len(problems) == 0 {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "no malformed external links found in %d articles\n", len(files))
+				return
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "FILE\tLINK\tPROBLEM")
+			for _, problem := range problems {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", problem.File, problem.Link, problem.Reason)
+			}
+			_ = w.Flush()
+			// 存在问题时以非 0 状态码退出，便于在 CI 中使用
+			os.Exit(1)
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(NewCheckLinksCmd())
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/exporter"
+	"github.com/narasux/goblog/pkg/handler"
+	"github.com/narasux/goblog/pkg/logging"
+	"github.com/narasux/goblog/pkg/ratelimit"
+	"github.com/narasux/goblog/pkg/router"
+	"github.com/narasux/goblog/pkg/storage"
+)
+
+// NewExportStaticCmd ...
+func NewExportStaticCmd() *cobra.Command {
+	var outDir, basePath string
+
+	exportCmd := cobra.Command{
+		Use:   "export-static",
+		Short: "Render all pages into a directory of static files (no database required).",
+		Run: func(cmd *cobra.Command, args []string) {
+			logging.InitLogger()
+			storage.InitBlogData()
+			logger := logging.GetSystemLogger()
+			store := storage.Current()
+
+			// 导出时所有请求都来自本地，不需要限流，也不应依赖 redis（即使配置了 redis 限流存储）
+			envs.RateLimitWebfePerMinute, envs.RateLimitAPIPerMinute = 0, 0
+			envs.RateLimitStore = ratelimit.StoreTypeMemory
+
+			engine, err := router.New()
+			if err != nil {
+				logger.Fatalf("failed to create router: %s", err)
+			}
+			exp := exporter.New(engine, outDir, basePath)
+			exp.Expand("/articles/:id", func() []string {
+				paths := make([]string, 0, len(store.Articles()))
+				for _, article := range store.Articles() {
+					paths = append(paths, "/articles/"+url.PathEscape(article.ID))
+				}
+				return paths
+			})
+			exp.Expand("/og/:file", func() []string {
+				paths := make([]string, 0, len(store.Articles()))
+				for _, article := range store.Articles() {
+					paths = append(paths, "/og/"+url.PathEscape(article.ID)+".png")
+				}
+				return paths
+			})
+			// 文章图片的各尺寸版本（加载文章时已解析）
+			exp.Expand("/images/:width/*filepath", store.Images().VariantURLs)
+			exp.Expand("/series/:id", func() []string {
+				return seriesPaths(store, "")
+			})
+			exp.Expand("/series/:id/rss", func() []string {
+				return seriesPaths(store, "/rss")
+			})
+			// 重定向（通配规则无法枚举，只导出精确规则）
+			for _, redirect := range store.Redirects() {
+				if !redirect.IsWildcard() {
+					exp.Include((&url.URL{Path: redirect.From}).EscapedPath())
+				}
+			}
+			exp.Expand("/categories/:name", func() []string {
+				paths := make([]string, 0, len(store.Categories()))
+				for _, category := range store.Categories() {
+					paths = append(paths, handler.CategoryPath(category.Name))
+				}
+				return paths
+			})
+			exp.Expand("/tags/:name", func() []string {
+				paths := make([]string, 0, len(store.Tags()))
+				for _, tag := range store.Tags() {
+					paths = append(paths, handler.TagPath(tag.Name))
+				}
+				return paths
+			})
+			exp.Expand("/archives/:year", func() []string {
+				paths := make([]string, 0, len(store.Archives()))
+				for _, year := range store.Archives() {
+					paths = append(paths, handler.ArchivePath(year.Year, 0))
+				}
+				return paths
+			})
+			exp.Expand("/archives/:year/:month", func() []string {
+				var paths []string
+				for _, year := range store.Archives() {
+					for _, month := range year.Months {
+						paths = append(paths, handler.ArchivePath(month.Year, month.Month))
+					}
+				}
+				return paths
+			})
+			// 文章列表的分类 / 标签过滤页面（兼容旧链接）
+			for _, category := range store.Categories() {
+				exp.Include("/articles?category=" + url.QueryEscape(category.Name))
+			}
+			for _, tag := range store.Tags() {
+				exp.Include("/articles?tag=" + url.QueryEscape(tag.Name))
+			}
+
+			if err := exp.Export(); err != nil {
+				logger.Fatalf("failed to export static site: %s", err)
+			}
+			logger.Infof("static site exported to %s", outDir)
+		},
+	}
+
+	exportCmd.Flags().StringVarP(&outDir, "output", "o", "dist", "directory to write the static site into")
+	exportCmd.Flags().StringVar(&basePath, "base-path", "", "path prefix the site is served under, e.g. /goblog")
+
+	return &exportCmd
+}
+
+// 所有系列页面的路径（suffix 为子路径，如 /rss）
+func seriesPaths(store *storage.Store, suffix string) []string {
+	paths := make([]string, 0, len(store.SeriesList()))
+	for _, series := range store.SeriesList() {
+		paths = append(paths, "/series/"+url.PathEscape(series.ID)+suffix)
+	}
+	return paths
+}
+
+func init() {
+	rootCmd.AddCommand(NewExportStaticCmd())
+}
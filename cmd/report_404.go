@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	"github.com/narasux/goblog/pkg/accesslog"
+	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/logging"
+)
+
+// NewReport404Cmd ...
+func NewReport404Cmd() *cobra.Command {
+	var logFiles []string
+	var top, topReferers int
+
+	reportCmd := cobra.Command{
+		Use:   "report-404",
+		Short: "Aggregate the most requested missing paths from the access log.",
+		Run: func(cmd *cobra.Command, args []string) {
+			// 不初始化日志文件，避免分析时写入新的日志
+			logger := logging.GetSystemLogger()
+
+			// 默认分析访问日志目录下的所有日志（包括切割归档的）
+			if len(logFiles) == 0 {
+				logFiles, _ = filepath.Glob(filepath.Join(envs.LogFileBaseDir, logging.LogTypeAccess, "*.log"))
+			}
+			if len(logFiles) == 0 {
+				logger.Fatalf("no access log found under %s", envs.LogFileBaseDir)
+			}
+
+			readers := make([]io.Reader, 0, len(logFiles))
+			for _, name := range logFiles {
+				f, err := os.Open(name)
+				if err != nil {
+					logger.Fatalf("failed to open access log: %s", err)
+				}
+				defer f.Close()
+				readers = append(readers, f)
+			}
+
+			stats, err := accesslog.ReportNotFound(io.MultiReader(readers...), top, topReferers)
+			if err != nil {
+				logger.Fatalf("failed to read access log: %s", err)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "COUNT\tPATH\tLAST SEEN\tTOP REFERERS")
+			for _, stat := range stats {
+				referers := lo.Map(stat.Referers, func(r accesslog.RefererStat, _ int) string {
+					return fmt.Sprintf("%s (%d)", r.Referer, r.Count)
+				})
+				_, _ = fmt.Fprintf(
+					w, "%d\t%s\t%s\t%s\n", stat.Count, stat.Path, stat.LastSeen, strings.Join(referers, ", "),
+				)
+			}
+			_ = w.Flush()
+		},
+	}
+
+	reportCmd.Flags().StringSliceVarP(
+		&logFiles, "log-file", "f", nil, "access log files to analyze, default all logs under LOG_FILE_BASE_DIR/access",
+	)
+	reportCmd.Flags().IntVarP(&top, "top", "n", 20, "number of paths to show, 0 means all")
+	reportCmd.Flags().IntVar(&topReferers, "referers", 3, "number of referers to show for each path")
+
+	return &reportCmd
+}
+
+func init() {
+	rootCmd.AddCommand(NewReport404Cmd())
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/narasux/goblog/pkg/envs"
 	"github.com/narasux/goblog/pkg/infras/database"
+	"github.com/narasux/goblog/pkg/infras/redis"
 	"github.com/narasux/goblog/pkg/logging"
+	"github.com/narasux/goblog/pkg/ratelimit"
 	"github.com/narasux/goblog/pkg/router"
 	"github.com/narasux/goblog/pkg/storage"
 )
@@ -17,12 +19,19 @@ var webServerCmd = &cobra.Command{
 	Use:   "webserver",
 	Short: "webserver start http server.",
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+
 		logging.InitLogger()
 		storage.InitBlogData()
-		database.InitDBClient(context.Background())
+		database.InitDBClient(ctx)
+		if envs.RateLimitStore == ratelimit.StoreTypeRedis {
+			redis.InitRedisClient(ctx)
+		}
 
 		color.Green("Starting server at http://0.0.0.0:%s/", envs.ServerPort)
-		router.InitRouter()
+		if err := router.InitRouter(); err != nil {
+			logging.GetSystemLogger().Fatalf("failed to start server: %s", err)
+		}
 	},
 }
 
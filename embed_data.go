@@ -0,0 +1,19 @@
+//go:build !noembeddata
+
+package main
+
+import (
+	"embed"
+
+	"github.com/narasux/goblog/pkg/assets"
+)
+
+// 内嵌博客数据（文章、元素周期表等），构建时指定 -tags noembeddata 可不内嵌，
+// 此时需要通过 BLOG_DATA_BASE_DIR 指定数据目录
+//
+//go:embed data
+var embeddedDataFS embed.FS
+
+func init() {
+	assets.Embed(assets.DataDir, embeddedDataFS)
+}
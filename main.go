@@ -1,9 +1,20 @@
 package main
 
 import (
+	"embed"
+
 	"github.com/narasux/goblog/cmd"
+	"github.com/narasux/goblog/pkg/assets"
 )
 
+// 内嵌模板与静态文件，使单个二进制即可部署（博客数据参见 embed_data.go）
+//
+//go:embed templates static
+var embeddedFS embed.FS
+
 func main() {
+	assets.Embed(assets.TemplatesDir, embeddedFS)
+	assets.Embed(assets.StaticDir, embeddedFS)
+
 	cmd.Execute()
 }
@@ -0,0 +1,87 @@
+// Package accesslog 访问日志（参见 middleware.Logger）分析工具
+package accesslog
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// 单行访问日志（仅解析需要的字段）
+type entry struct {
+	Path    string `json:"path"`
+	Referer string `json:"referer"`
+	Status  int    `json:"status"`
+	Time    string `json:"time"`
+}
+
+// RefererStat 来源页面统计
+type RefererStat struct {
+	Referer string
+	Count   int
+}
+
+// NotFoundStat 404 路径统计
+type NotFoundStat struct {
+	Path  string
+	Count int
+	// LastSeen 最近一次访问的时间
+	LastSeen string
+	// Referers 访问次数最多的来源页面（按次数降序，不含空来源）
+	Referers []RefererStat
+}
+
+// ReportNotFound 统计访问日志中的 404 请求，返回访问次数最多的 top 个路径（top <= 0 表示不限制），
+// 每个路径最多保留 topReferers 个来源页面，无法解析的行会被忽略
+func ReportNotFound(r io.Reader, top, topReferers int) ([]NotFoundStat, error) {
+	stats := map[string]*NotFoundStat{}
+	referers := map[string]map[string]int{}
+
+	scanner := bufio.NewScanner(r)
+	// 请求 / 响应体可能较长
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var e entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Status != http.StatusNotFound {
+			continue
+		}
+		stat, ok := stats[e.Path]
+		if !ok {
+			stat = &NotFoundStat{Path: e.Path}
+			stats[e.Path] = stat
+			referers[e.Path] = map[string]int{}
+		}
+		stat.Count++
+		stat.LastSeen = max(stat.LastSeen, e.Time)
+		if e.Referer != "" {
+			referers[e.Path][e.Referer]++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]NotFoundStat, 0, len(stats))
+	for path, stat := range stats {
+		for referer, count := range referers[path] {
+			stat.Referers = append(stat.Referers, RefererStat{Referer: referer, Count: count})
+		}
+		slices.SortFunc(stat.Referers, func(a, b RefererStat) int {
+			return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Referer, b.Referer))
+		})
+		if topReferers >= 0 && len(stat.Referers) > topReferers {
+			stat.Referers = stat.Referers[:topReferers]
+		}
+		results = append(results, *stat)
+	}
+	slices.SortFunc(results, func(a, b NotFoundStat) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Path, b.Path))
+	})
+	if top > 0 && len(results) > top {
+		results = results[:top]
+	}
+	return results, nil
+}
@@ -0,0 +1,41 @@
+package accesslog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/accesslog"
+)
+
+func TestReportNotFound(t *testing.T) {
+	logs := strings.Join([]string{
+		`{"level":"warning","path":"/articles/k8s","referer":"https://a.com/","status":404,"time":"2026-10-01 10:00:00"}`,
+		`{"level":"warning","path":"/articles/k8s","referer":"https://b.com/","status":404,"time":"2026-10-03 10:00:00"}`,
+		`{"level":"warning","path":"/articles/k8s","referer":"https://b.com/","status":404,"time":"2026-10-02 10:00:00"}`,
+		`{"level":"warning","path":"/articles/k8s","referer":"","status":404,"time":"2026-10-02 11:00:00"}`,
+		`{"level":"warning","path":"/wp-login.php","referer":"","status":404,"time":"2026-10-01 09:00:00"}`,
+		`{"level":"info","path":"/articles","referer":"","status":200,"time":"2026-10-01 09:00:00"}`,
+		`time="2026-10-01 09:00:00" level=info msg="not a json line"`,
+		`{"level":"warning","path":"/feed.xml","referer":"https://c.com/","status":404,"time":"2026-10-01 08:00:00"}`,
+	}, "\n")
+
+	stats, err := accesslog.ReportNotFound(strings.NewReader(logs), 2, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []accesslog.NotFoundStat{
+		{
+			Path:     "/articles/k8s",
+			Count:    4,
+			LastSeen: "2026-10-03 10:00:00",
+			Referers: []accesslog.RefererStat{{Referer: "https://b.com/", Count: 2}},
+		},
+		// 次数相同时按路径排序
+		{
+			Path:     "/feed.xml",
+			Count:    1,
+			LastSeen: "2026-10-01 08:00:00",
+			Referers: []accesslog.RefererStat{{Referer: "https://c.com/", Count: 1}},
+		},
+	}, stats)
+}
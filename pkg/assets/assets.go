@@ -0,0 +1,94 @@
+// Package assets 提供模板、静态文件、博客数据的统一文件系统抽象
+//
+// 默认使用 main 包通过 go:embed 内嵌到二进制中的文件，以便单个二进制即可部署；
+// 若设置了 TMPL_FILE_BASE_DIR / STATIC_FILE_BASE_DIR / BLOG_DATA_BASE_DIR 环境变量，
+// 则使用对应的磁盘目录覆盖（便于不重新构建即可更新文章）；
+// 两者都没有时（如单元测试），回退到源码目录
+package assets
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/utils/pathx"
+)
+
+const (
+	// TemplatesDir 模板文件目录名
+	TemplatesDir = "templates"
+	// StaticDir 静态文件目录名
+	StaticDir = "static"
+	// DataDir 博客数据目录名
+	DataDir = "data"
+)
+
+var (
+	embedded   = map[string]fs.FS{}
+	embeddedMu sync.RWMutex
+)
+
+// Embed 注册内嵌的文件系统，fsys 根目录下需包含 name 目录（如 go:embed templates 得到的 embed.FS）
+func Embed(name string, fsys fs.FS) {
+	sub, err := fs.Sub(fsys, name)
+	if err != nil {
+		panic(err)
+	}
+
+	embeddedMu.Lock()
+	defer embeddedMu.Unlock()
+	embedded[name] = sub
+}
+
+// Templates 模板文件系统
+func Templates() fs.FS {
+	return open(TemplatesDir)
+}
+
+// Static 静态文件文件系统
+func Static() fs.FS {
+	return open(StaticDir)
+}
+
+// Data 博客数据文件系统
+func Data() fs.FS {
+	return open(DataDir)
+}
+
+// DiskDir 获取指定目录在磁盘上的路径（环境变量覆盖的目录，否则为源码目录），
+// 用于生成预压缩文件等需要写磁盘的场景
+func DiskDir(name string) string {
+	if dir := overrideDir(name); dir != "" {
+		return dir
+	}
+	return filepath.Join(pathx.GetCurPKGPath(), "../..", name)
+}
+
+func open(name string) fs.FS {
+	if dir := overrideDir(name); dir != "" {
+		return os.DirFS(dir)
+	}
+
+	embeddedMu.RLock()
+	fsys, ok := embedded[name]
+	embeddedMu.RUnlock()
+	if ok {
+		return fsys
+	}
+	return os.DirFS(DiskDir(name))
+}
+
+// 环境变量指定的磁盘目录
+func overrideDir(name string) string {
+	switch name {
+	case TemplatesDir:
+		return envs.TmplFileBaseDir
+	case StaticDir:
+		return envs.StaticFileBaseDir
+	case DataDir:
+		return envs.BlogDataBaseDir
+	}
+	return ""
+}
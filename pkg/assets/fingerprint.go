@@ -0,0 +1,29 @@
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+// 静态文件指纹（文件路径 -> 内容哈希）
+var staticFingerprints sync.Map
+
+// StaticFingerprint 静态文件的指纹（内容哈希的前 10 位），用作 URL 中的 ?v= 版本参数，文件不存在时返回空字符串
+func StaticFingerprint(name string) string {
+	name = strings.TrimPrefix(name, "/")
+	if fingerprint, ok := staticFingerprints.Load(name); ok {
+		return fingerprint.(string)
+	}
+
+	content, err := fs.ReadFile(Static(), name)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(content)
+	fingerprint := hex.EncodeToString(sum[:])[:10]
+	staticFingerprints.Store(name, fingerprint)
+	return fingerprint
+}
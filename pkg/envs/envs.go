@@ -36,26 +36,54 @@ var (
 	// BaseDir 项目根目录
 	BaseDir = envx.Get("BASE_DIR", baseDir)
 
-	// TmplFileBaseDir 模板文件目录
-	TmplFileBaseDir = envx.Get("TMPL_FILE_BASE_DIR", filepath.Join(pathx.GetCurPKGPath(), "../../templates"))
+	// TmplFileBaseDir 模板文件目录，为空则使用内嵌到二进制中的模板（参见 assets 包）
+	TmplFileBaseDir = envx.Get("TMPL_FILE_BASE_DIR", "")
 
-	// StaticFileBaseDir 静态文件目录
-	StaticFileBaseDir = envx.Get("STATIC_FILE_BASE_DIR", filepath.Join(pathx.GetCurPKGPath(), "../../static"))
+	// StaticFileBaseDir 静态文件目录，为空则使用内嵌到二进制中的静态文件（参见 assets 包）
+	StaticFileBaseDir = envx.Get("STATIC_FILE_BASE_DIR", "")
 
-	// BlogDataBaseDir 博客文章内容存放目录
-	BlogDataBaseDir = envx.Get("BLOG_DATA_BASE_DIR", filepath.Join(pathx.GetCurPKGPath(), "../../data"))
+	// BlogDataBaseDir 博客文章内容存放目录，为空则使用内嵌到二进制中的博客数据（参见 assets 包）
+	BlogDataBaseDir = envx.Get("BLOG_DATA_BASE_DIR", "")
 
 	// LogFileBaseDir 日志存放目录
 	LogFileBaseDir = envx.Get("LOG_FILE_BASE_DIR", filepath.Join(pathx.GetCurPKGPath(), "../../logs"))
 
+	// ImageCacheDir 文章图片各尺寸版本（按需生成）的缓存目录，默认为系统临时目录（需要可写，容器中建议挂载数据卷）
+	ImageCacheDir = envx.Get("IMAGE_CACHE_DIR", filepath.Join(os.TempDir(), "goblog/images"))
+
 	// LogLevel 日志等级（panic/fatal/error/warn/info/debug/trace）
 	LogLevel = envx.Get("LOG_LEVEL", "warn")
 
 	// ContactEmail 联系邮箱
 	ContactEmail = envx.Get("CONTACT_EMAIL", "suzh9@mail2.sysu.edu.cn")
 
-	// RealClientIPHeaderKey Header 中真实客户端 IP 键（适用于类似 Nginx 转发的情况）为空则使用默认的 ClientIP
-	RealClientIPHeaderKey = envx.Get("REAL_CLIENT_IP_HEADER_KEY", "")
+	// RealClientIPHeaderKey 可信代理写入真实客户端 IP 的转发头（X-Forwarded-For / Forwarded / X-Real-IP 等），
+	// 仅当请求来自可信代理时才会读取，且只读取该头，不会回退到其他（可能由客户端伪造的）转发头
+	// 默认与 nginx.conf 中设置的 X-Real-IP 一致
+	RealClientIPHeaderKey = envx.Get("REAL_CLIENT_IP_HEADER_KEY", "X-Real-IP")
+
+	// TrustedProxies 可信代理的 IP / CIDR 列表（逗号分隔），默认只信任本机（代理部署在其他机器 / 容器时需要配置）
+	// 来自非可信代理的请求，将直接使用 TCP 连接的对端地址作为客户端 IP
+	TrustedProxies = envx.GetList("TRUSTED_PROXIES", "127.0.0.0/8,::1/128")
+
+	// ClientSideHighlight 是否在浏览器中使用 highlight.js 高亮代码（代码块默认已在服务端高亮，
+	// 启用后仅用于高亮没有语言标识的代码块）
+	ClientSideHighlight = envx.GetBool("CLIENT_SIDE_HIGHLIGHT", false)
+
+	// MathJaxScriptURL 渲染数学公式的 MathJax 脚本地址（仅在文章包含公式时加载），
+	// 也可以下载后放到静态文件目录中，配置为 /static/js/xxx.js
+	MathJaxScriptURL = envx.Get("MATHJAX_SCRIPT_URL", "https://cdn.jsdelivr.net/npm/mathjax@3/es5/tex-svg.js")
+
+	// ReadingSpeedCJK 阅读速度（中日韩字符数 / 分钟），用于估算文章阅读时间
+	ReadingSpeedCJK = envx.GetInt("READING_SPEED_CJK", 300)
+	// ReadingSpeedLatin 阅读速度（拉丁文单词数 / 分钟）
+	ReadingSpeedLatin = envx.GetInt("READING_SPEED_LATIN", 200)
+
+	// RelatedArticlesCount 文章详情页展示的相关文章数量
+	RelatedArticlesCount = envx.GetInt("RELATED_ARTICLES_COUNT", 5)
+
+	// StrictLinkCheck 文章中存在失效的站内链接（文章 / 标题锚点不存在）时是否加载失败，否则只打印警告
+	StrictLinkCheck = envx.GetBool("STRICT_LINK_CHECK", true)
 
 	// ========== 数据库相关配置 ==========
 
@@ -72,6 +100,41 @@ var (
 	// MysqlCharSet MySQL 字符集
 	MysqlCharSet = envx.Get("MYSQL_CHARSET", "utf8mb4")
 
+	// ========== Redis 相关配置（可选，目前仅用于多实例共享限流状态） ==========
+
+	// RedisAddr Redis 地址
+	RedisAddr = envx.Get("REDIS_ADDR", "localhost:6379")
+	// RedisPassword Redis 密码
+	RedisPassword = envx.Get("REDIS_PASSWORD", "")
+	// RedisDB Redis DB 编号
+	RedisDB = envx.GetInt("REDIS_DB", 0)
+
+	// ========== 限流相关配置（按客户端 IP 限流，每分钟请求数为 0 表示不限流） ==========
+
+	// RateLimitStore 限流状态存储（memory / redis），多实例部署时应使用 redis
+	RateLimitStore = envx.Get("RATE_LIMIT_STORE", "memory")
+	// RateLimitAPIPerMinute API 路由每分钟请求数
+	RateLimitAPIPerMinute = envx.GetInt("RATE_LIMIT_API_PER_MINUTE", 30)
+	// RateLimitAPIBurst API 路由允许的突发请求数
+	RateLimitAPIBurst = envx.GetInt("RATE_LIMIT_API_BURST", 10)
+	// RateLimitWebfePerMinute 页面路由每分钟请求数
+	RateLimitWebfePerMinute = envx.GetInt("RATE_LIMIT_WEBFE_PER_MINUTE", 120)
+	// RateLimitWebfeBurst 页面路由允许的突发请求数
+	RateLimitWebfeBurst = envx.GetInt("RATE_LIMIT_WEBFE_BURST", 60)
+
+	// ========== HTTP 缓存相关配置（Cache-Control 头，为空表示不设置） ==========
+
+	// CacheControlWebfe 页面路由，默认每次都需要校验（ETag / Last-Modified），未变化时响应 304
+	CacheControlWebfe = envx.Get("CACHE_CONTROL_WEBFE", "no-cache")
+	// CacheControlAPI API 路由
+	CacheControlAPI = envx.Get("CACHE_CONTROL_API", "no-store")
+	// CacheControlStatic 静态文件
+	CacheControlStatic = envx.Get("CACHE_CONTROL_STATIC", "public, max-age=86400")
+	// CacheControlFingerprintedStatic 带指纹（文件名包含哈希 / ?v= 版本参数与文件指纹一致）的静态文件
+	CacheControlFingerprintedStatic = envx.Get(
+		"CACHE_CONTROL_FINGERPRINTED_STATIC", "public, max-age=31536000, immutable",
+	)
+
 	// ========== 以下 MyGo 配置有助于你的网站出现在 Google、Baidu 的搜索结果中 ==========
 
 	// GoogleSiteVerificationCode Google 网站所有权验证码（HTML 标签验证方式）
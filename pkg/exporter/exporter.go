@@ -0,0 +1,369 @@
+// Package exporter 将 web 服务的所有页面渲染为静态文件，以便部署到对象存储 / GitHub Pages 等无后端的环境
+package exporter
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"maps"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/narasux/goblog/pkg/assets"
+	"github.com/narasux/goblog/pkg/logging"
+	"github.com/narasux/goblog/pkg/utils/compressx"
+)
+
+// 不需要导出的路由前缀（静态文件直接复制，API 在静态站点中不可用）
+var skippedRoutePrefixes = []string{"/static/", "/apis/"}
+
+// 用于渲染 404 页面的路径（任意不存在的路径均可）
+const notFoundPath = "/__goblog_export_not_found__"
+
+// Expander 路由参数展开器，返回带参数的路由（如 /articles/:id）对应的所有实际路径
+type Expander func() []string
+
+// 渲染得到的页面
+type page struct {
+	// 导出后的访问路径（如 /rss -> /rss.xml）
+	target      string
+	contentType string
+	body        []byte
+	// 重定向的目标路径（响应为重定向时）
+	redirect string
+}
+
+// Exporter 静态站点导出器
+type Exporter struct {
+	engine *gin.Engine
+	// 导出目录
+	outDir string
+	// 站点部署的路径前缀，如 GitHub Pages 项目站点的 /goblog，为空表示部署在根路径
+	basePath string
+	// 路由参数展开器（路由 -> 展开器）
+	expanders map[string]Expander
+	// 额外需要导出的路径（路由无法枚举的，如带查询参数的过滤页面）
+	extraPaths []string
+	// 原始路径 -> 导出后的访问路径
+	rewrites map[string]string
+}
+
+// New ...
+func New(engine *gin.Engine, outDir, basePath string) *Exporter {
+	return &Exporter{
+		engine:    engine,
+		outDir:    outDir,
+		basePath:  strings.TrimSuffix(basePath, "/"),
+		expanders: map[string]Expander{},
+		rewrites:  map[string]string{},
+	}
+}
+
+// Expand 注册路由参数展开器
+func (e *Exporter) Expand(route string, expander Expander) *Exporter {
+	e.expanders[route] = expander
+	return e
+}
+
+// Include 注册额外需要导出的路径
+func (e *Exporter) Include(paths ...string) *Exporter {
+	e.extraPaths = append(e.extraPaths, paths...)
+	return e
+}
+
+// Export 执行导出
+func (e *Exporter) Export() error {
+	logger := logging.GetSystemLogger()
+
+	var pages []page
+	for _, p := range e.collectPaths() {
+		pg, err := e.render(p, http.StatusOK, http.StatusMovedPermanently)
+		if err != nil {
+			return err
+		}
+		pages = append(pages, pg)
+	}
+
+	// 404 页面（GitHub Pages 等会自动使用根目录下的 404.html）
+	notFound, err := e.render(notFoundPath, http.StatusNotFound, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	notFound.target = "/404.html"
+	pages = append(pages, notFound)
+
+	// 所有页面渲染完成，确定了路径映射关系后，才能改写链接
+	for _, pg := range pages {
+		body := e.rewriteLinks(pg.contentType, pg.body)
+		if pg.redirect != "" {
+			body = e.redirectPage(pg.redirect)
+		}
+		if err = e.write(pg.target, body); err != nil {
+			return err
+		}
+		logger.Infof("exported %s", pg.target)
+	}
+	return e.copyStatic()
+}
+
+// collectPaths 收集需要导出的路径：所有 GET 路由（带参数的通过展开器展开）及额外注册的路径
+func (e *Exporter) collectPaths() []string {
+	logger := logging.GetSystemLogger()
+
+	var paths []string
+	for _, route := range e.engine.Routes() {
+		if route.Method != http.MethodGet || isSkipped(route.Path) {
+			continue
+		}
+		if !strings.ContainsAny(route.Path, ":*") {
+			paths = append(paths, route.Path)
+			continue
+		}
+		expander, ok := e.expanders[route.Path]
+		if !ok {
+			logger.Warnf("route %s has params but no expander registered, skip", route.Path)
+			continue
+		}
+		paths = append(paths, expander()...)
+	}
+	return append(paths, e.extraPaths...)
+}
+
+func isSkipped(routePath string) bool {
+	for _, prefix := range skippedRoutePrefixes {
+		if strings.HasPrefix(routePath, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// render 渲染指定路径，并确定导出后的访问路径
+func (e *Exporter) render(rawPath string, expectedStatus ...int) (page, error) {
+	req := httptest.NewRequest(http.MethodGet, rawPath, nil)
+	w := httptest.NewRecorder()
+	e.engine.ServeHTTP(w, req)
+
+	statusOK := false
+	for _, status := range expectedStatus {
+		statusOK = statusOK || w.Code == status
+	}
+	if !statusOK {
+		return page{}, errors.Errorf("failed to render %s, status: %d", rawPath, w.Code)
+	}
+
+	// 静态站点无法响应 301，导出为跳转到目标路径的 HTML 页面
+	if w.Code == http.StatusMovedPermanently {
+		return page{
+			target:      e.targetOf(rawPath, "text/html"),
+			contentType: "text/html; charset=utf-8",
+			redirect:    w.Header().Get("Location"),
+		}, nil
+	}
+
+	contentType := w.Header().Get("Content-Type")
+	target := e.targetOf(rawPath, contentType)
+	if key := canonicalLink(rawPath); target != key {
+		e.rewrites[key] = target
+	}
+	return page{target: target, contentType: contentType, body: w.Body.Bytes()}, nil
+}
+
+// targetOf 确定导出后的访问路径：
+// - HTML 页面导出为 <path>/index.html，访问路径以 / 结尾（静态站点会自动寻找 index.html）
+// - 带查询参数的页面（如 /articles?tag=Go）导出为 /articles/tag/Go/
+// - 其他没有后缀的响应（如 /rss）根据类型补充后缀（/rss.xml）
+func (e *Exporter) targetOf(rawPath, contentType string) string {
+	p, rawQuery, _ := strings.Cut(rawPath, "?")
+	if rawQuery != "" {
+		query, _ := url.ParseQuery(rawQuery)
+		for _, key := range slices.Sorted(maps.Keys(query)) {
+			p = path.Join(p, url.PathEscape(key), url.PathEscape(query.Get(key)))
+		}
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "text/html" {
+		return strings.TrimSuffix(p, "/") + "/"
+	}
+	if path.Ext(p) == "" {
+		return p + extensionOf(mediaType)
+	}
+	return p
+}
+
+// extensionOf 获取响应类型对应的文件后缀
+func extensionOf(mediaType string) string {
+	switch mediaType {
+	case "application/xml", "text/xml":
+		return ".xml"
+	case "text/plain":
+		return ".txt"
+	case "application/json":
+		return ".json"
+	}
+	if exts, _ := mime.ExtensionsByType(mediaType); len(exts) != 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// 页面中的站内链接（以 / 开头的 href / src）
+var linkRegex = regexp.MustCompile(`(href|src)="(/[^"]*)"`)
+
+// 响应式图片的候选地址列表（如 srcset="/a.png 480w, /b.png 960w"）
+var srcSetRegex = regexp.MustCompile(`(srcset)="([^"]*)"`)
+
+// rewriteLinks 改写 HTML 中的站内链接，使其在静态站点中可用
+func (e *Exporter) rewriteLinks(contentType string, body []byte) []byte {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "text/html" {
+		return body
+	}
+	body = linkRegex.ReplaceAllFunc(body, func(match []byte) []byte {
+		sub := linkRegex.FindSubmatch(match)
+		return []byte(string(sub[1]) + `="` + e.rewriteLink(string(sub[2])) + `"`)
+	})
+	return srcSetRegex.ReplaceAllFunc(body, func(match []byte) []byte {
+		sub := srcSetRegex.FindSubmatch(match)
+		candidates := strings.Split(string(sub[2]), ",")
+		for idx, candidate := range candidates {
+			url, descriptor, _ := strings.Cut(strings.TrimSpace(candidate), " ")
+			if strings.HasPrefix(url, "/") {
+				url = e.rewriteLink(url)
+			}
+			candidates[idx] = strings.TrimSpace(url + " " + descriptor)
+		}
+		return []byte(string(sub[1]) + `="` + strings.Join(candidates, ", ") + `"`)
+	})
+}
+
+func (e *Exporter) rewriteLink(link string) string {
+	// 协议相对链接（//example.com）不是站内链接
+	if strings.HasPrefix(link, "//") {
+		return link
+	}
+	// html/template 会将 & 转义为 &amp;，需先还原
+	link = strings.ReplaceAll(link, "&amp;", "&")
+
+	rest, fragment, _ := strings.Cut(link, "#")
+	p, rawQuery, _ := strings.Cut(rest, "?")
+
+	switch {
+	case strings.HasPrefix(p, "/static/"):
+		// 静态文件保留查询参数（指纹）
+	case e.rewrites[canonicalLink(rest)] != "":
+		p, rawQuery = e.rewrites[canonicalLink(rest)], ""
+	case e.rewrites[p] != "":
+		p, rawQuery = e.rewrites[p], ""
+	case rawQuery == "":
+		// HTML 页面导出为目录
+		if path.Ext(p) == "" {
+			p = strings.TrimSuffix(p, "/") + "/"
+		}
+	default:
+		// 未导出的查询参数页面，只能退化为不带参数的页面
+		p, rawQuery = strings.TrimSuffix(p, "/")+"/", ""
+	}
+
+	link = e.basePath + p
+	if rawQuery != "" {
+		link += "?" + rawQuery
+	}
+	if fragment != "" {
+		link += "#" + fragment
+	}
+	return strings.ReplaceAll(link, "&", "&amp;")
+}
+
+// redirectPage 生成跳转到指定路径的 HTML 页面
+func (e *Exporter) redirectPage(location string) []byte {
+	if strings.HasPrefix(location, "/") {
+		location = e.rewriteLink(location)
+	} else {
+		location = html.EscapeString(location)
+	}
+	return []byte(fmt.Sprintf(
+		`<!doctype html><html><head><meta charset="utf-8" /><meta http-equiv="refresh" content="0; url=%[1]s" />`+
+			`<link rel="canonical" href="%[1]s" /></head><body><a href="%[1]s">%[1]s</a></body></html>`,
+		location,
+	))
+}
+
+// canonicalLink 规范化链接，使不同编码方式（如 %e6 / %E6 / 原始字符）的相同链接可以匹配
+func canonicalLink(link string) string {
+	p, rawQuery, _ := strings.Cut(link, "?")
+	if unescaped, err := url.PathUnescape(p); err == nil {
+		p = unescaped
+	}
+	if rawQuery == "" {
+		return p
+	}
+	query, _ := url.ParseQuery(rawQuery)
+	return p + "?" + query.Encode()
+}
+
+// write 将内容写入访问路径对应的文件
+func (e *Exporter) write(target string, content []byte) error {
+	decoded, err := url.PathUnescape(target)
+	if err != nil {
+		decoded = target
+	}
+	if strings.HasSuffix(decoded, "/") {
+		decoded += "index.html"
+	}
+
+	filePath := filepath.Join(e.outDir, filepath.FromSlash(decoded))
+	if err = os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, content, 0o644)
+}
+
+// copyStatic 复制静态文件（预压缩文件在静态站点中无法协商使用，跳过）
+func (e *Exporter) copyStatic() error {
+	staticFS := assets.Static()
+	return fs.WalkDir(staticFS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		for _, encoding := range compressx.Encodings {
+			if strings.HasSuffix(name, compressx.Extension(encoding)) {
+				return nil
+			}
+		}
+
+		src, err := staticFS.Open(name)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		filePath := filepath.Join(e.outDir, assets.StaticDir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			return err
+		}
+		dst, err := os.Create(filePath)
+		if err != nil {
+			return err
+		}
+		defer dst.Close()
+
+		_, err = io.Copy(dst, src)
+		return err
+	})
+}
@@ -0,0 +1,169 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestExporter(basePath string) *Exporter {
+	e := New(nil, "", basePath)
+	// 渲染阶段确定的路径映射（原始路径 -> 导出后的访问路径）
+	e.rewrites = map[string]string{
+		"/rss":             "/rss.xml",
+		"/articles?tag=Go": "/articles/tag/Go/",
+		"/tags/云原生":        "/tags/%E4%BA%91%E5%8E%9F%E7%94%9F/",
+	}
+	return e
+}
+
+func TestTargetOf(t *testing.T) {
+	e := newTestExporter("")
+
+	cases := []struct {
+		rawPath     string
+		contentType string
+		expected    string
+	}{
+		{"/", "text/html; charset=utf-8", "/"},
+		{"/articles/go-leak", "text/html; charset=utf-8", "/articles/go-leak/"},
+		{"/articles/go-leak/", "text/html", "/articles/go-leak/"},
+		{"/tags/%E4%BA%91%E5%8E%9F%E7%94%9F", "text/html; charset=utf-8", "/tags/%E4%BA%91%E5%8E%9F%E7%94%9F/"},
+		// 查询参数按参数名排序后转为路径
+		{"/articles?tag=Go", "text/html; charset=utf-8", "/articles/tag/Go/"},
+		{"/articles?tag=K8s&category=云原生", "text/html", "/articles/category/%E4%BA%91%E5%8E%9F%E7%94%9F/tag/K8s/"},
+		{"/articles?tag=CI/CD", "text/html", "/articles/tag/CI%2FCD/"},
+		// 没有后缀的根据类型补充后缀
+		{"/rss", "application/xml; charset=utf-8", "/rss.xml"},
+		{"/series/k8s/rss", "text/xml", "/series/k8s/rss.xml"},
+		{"/periodic-table/data", "application/json; charset=utf-8", "/periodic-table/data.json"},
+		{"/version", "text/plain; charset=utf-8", "/version.txt"},
+		// 已有后缀的保持不变
+		{"/robots.txt", "text/plain; charset=utf-8", "/robots.txt"},
+		{"/sitemap.xml", "application/xml", "/sitemap.xml"},
+		{"/og/go-leak.png", "image/png", "/og/go-leak.png"},
+		{"/unknown", "", "/unknown"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, e.targetOf(c.rawPath, c.contentType), c.rawPath)
+	}
+}
+
+func TestRewriteLinks(t *testing.T) {
+	cases := []struct {
+		name     string
+		basePath string
+		body     string
+		expected string
+	}{
+		{
+			name:     "html page exported as directory",
+			body:     `<a href="/articles/go-leak">Go</a>`,
+			expected: `<a href="/articles/go-leak/">Go</a>`,
+		},
+		{
+			name:     "fragment is kept",
+			body:     `<a href="/articles/go-leak#pprof-工具">pprof</a>`,
+			expected: `<a href="/articles/go-leak/#pprof-工具">pprof</a>`,
+		},
+		{
+			name:     "rewritten target",
+			body:     `<link href="/rss" /><a href="/articles?tag=Go">Go</a>`,
+			expected: `<link href="/rss.xml" /><a href="/articles/tag/Go/">Go</a>`,
+		},
+		{
+			name:     "escaped link matches unescaped rewrite",
+			body:     `<a href="/tags/%e4%ba%91%e5%8e%9f%e7%94%9f">云原生</a>`,
+			expected: `<a href="/tags/%E4%BA%91%E5%8E%9F%E7%94%9F/">云原生</a>`,
+		},
+		{
+			name:     "query page not exported falls back to page without query",
+			body:     `<a href="/articles?category=a&amp;tag=b">a</a>`,
+			expected: `<a href="/articles/">a</a>`,
+		},
+		{
+			name:     "static file keeps fingerprint query",
+			body:     `<script src="/static/js/main.js?v=abc&amp;t=1"></script>`,
+			expected: `<script src="/static/js/main.js?v=abc&amp;t=1"></script>`,
+		},
+		{
+			name:     "file with extension is not a directory",
+			body:     `<img src="/og/go-leak.png" />`,
+			expected: `<img src="/og/go-leak.png" />`,
+		},
+		{
+			name:     "external and protocol relative links are untouched",
+			body:     `<a href="https://example.com/a">a</a><script src="//cdn.example.com/a.js"></script><a href="#top">top</a>`,
+			expected: `<a href="https://example.com/a">a</a><script src="//cdn.example.com/a.js"></script><a href="#top">top</a>`,
+		},
+		{
+			name:     "base path",
+			basePath: "/goblog/",
+			body:     `<a href="/">home</a><a href="/rss">rss</a><link href="/static/css/a.css?v=1" />`,
+			expected: `<a href="/goblog/">home</a><a href="/goblog/rss.xml">rss</a><link href="/goblog/static/css/a.css?v=1" />`,
+		},
+		{
+			name:     "srcset candidates",
+			basePath: "/goblog",
+			body:     `<img srcset="/images/480/a/b.png 480w,/images/960/a/b.png 960w, https://example.com/c.png 2x" />`,
+			expected: `<img srcset="/goblog/images/480/a/b.png 480w, /goblog/images/960/a/b.png 960w, https://example.com/c.png 2x" />`,
+		},
+		{
+			name:     "srcset without descriptor",
+			basePath: "/goblog",
+			body:     `<source srcset="/images/480/a/b.webp" />`,
+			expected: `<source srcset="/goblog/images/480/a/b.webp" />`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := newTestExporter(c.basePath)
+			assert.Equal(t, c.expected, string(e.rewriteLinks("text/html; charset=utf-8", []byte(c.body))))
+		})
+	}
+
+	// 非 HTML 响应原样返回
+	e := newTestExporter("/goblog")
+	body := `<link href="/articles/go-leak" />`
+	assert.Equal(t, body, string(e.rewriteLinks("application/xml", []byte(body))))
+}
+
+func TestRedirectPage(t *testing.T) {
+	cases := []struct {
+		name     string
+		basePath string
+		location string
+		expected string
+	}{
+		{
+			name:     "internal page",
+			location: "/articles/new-id",
+			expected: "/articles/new-id/",
+		},
+		{
+			name:     "internal page with base path and fragment",
+			basePath: "/goblog",
+			location: "/articles/new-id#intro",
+			expected: "/goblog/articles/new-id/#intro",
+		},
+		{
+			name:     "rewritten target",
+			basePath: "/goblog",
+			location: "/rss",
+			expected: "/goblog/rss.xml",
+		},
+		{
+			name:     "external location is escaped",
+			location: `https://example.com/?a=1&b="><script>`,
+			expected: "https://example.com/?a=1&amp;b=&#34;&gt;&lt;script&gt;",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			page := string(newTestExporter(c.basePath).redirectPage(c.location))
+			assert.Contains(t, page, `<meta http-equiv="refresh" content="0; url=`+c.expected+`" />`)
+			assert.Contains(t, page, `<link rel="canonical" href="`+c.expected+`" />`)
+			assert.Contains(t, page, `<a href="`+c.expected+`">`+c.expected+`</a>`)
+		})
+	}
+}
@@ -8,11 +8,17 @@ import (
 
 	"github.com/narasux/goblog/pkg/infras/database"
 	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/storage"
 	"github.com/narasux/goblog/pkg/utils/ginx"
 )
 
 // LikeArticle 点赞文章
 func LikeArticle(c *gin.Context) {
+	if !database.Available() {
+		ginx.SetErrResp(c, http.StatusServiceUnavailable, "like is unavailable now")
+		return
+	}
+
 	clientIP := ginx.GetClientIP(c)
 	articleID := c.Param("id")
 	db := database.Client(c.Request.Context())
@@ -40,3 +46,27 @@ func LikeArticle(c *gin.Context) {
 	}
 	ginx.SetResp(c, http.StatusNoContent, nil)
 }
+
+// GetArticleDetail 获取文章详情（含目录，字数，阅读时间等）
+//
+// Deprecated: 仅为兼容旧客户端保留，新客户端请使用 /apis/v1/articles/:id
+func GetArticleDetail(c *gin.Context) {
+	article := storage.Current().Article(c.Param("id"))
+	if article == nil {
+		ginx.SetErrResp(c, http.StatusNotFound, "article not found")
+		return
+	}
+	ginx.SetResp(c, http.StatusOK, article)
+}
+
+// GetArticleTOC 获取文章目录
+//
+// Deprecated: 仅为兼容旧客户端保留，新客户端请使用 /apis/v1/articles/:id（详情中包含目录）
+func GetArticleTOC(c *gin.Context) {
+	article := storage.Current().Article(c.Param("id"))
+	if article == nil {
+		ginx.SetErrResp(c, http.StatusNotFound, "article not found")
+		return
+	}
+	ginx.SetResp(c, http.StatusOK, article.TOC)
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"cmp"
+	"io/fs"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+
+	"github.com/narasux/goblog/pkg/assets"
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/storage"
+	"github.com/narasux/goblog/pkg/utils/ginx"
+)
+
+// OpenAPIDocPath v1 API 的 OpenAPI 文档（位于静态文件目录中）
+const OpenAPIDocPath = "openapi/v1.json"
+
+// 文章列表支持的排序字段（加 - 前缀表示降序）
+var articleSortFields = map[string]func(a, b *model.Article) int{
+	"updatedAt": func(a, b *model.Article) int { return cmp.Compare(a.UpdatedAt, b.UpdatedAt) },
+	"title":     func(a, b *model.Article) int { return cmp.Compare(a.Title, b.Title) },
+	"wordCount": func(a, b *model.Article) int { return cmp.Compare(a.WordCount, b.WordCount) },
+}
+
+// 文章列表的默认排序（最近更新的在前，与 storage.Store 中文章的顺序一致，无需再排序）
+const defaultArticleSort = "-updatedAt"
+
+// articleSummary 文章摘要（文章列表中不返回正文，目录等）
+type articleSummary struct {
+	ID          string   `json:"id"`
+	Category    string   `json:"category"`
+	Tags        []string `json:"tags"`
+	Title       string   `json:"title"`
+	Desc        string   `json:"desc"`
+	UpdatedAt   string   `json:"updateAt"`
+	Cover       string   `json:"cover,omitempty"`
+	WordCount   int      `json:"wordCount"`
+	ReadingTime int      `json:"readingTime"`
+}
+
+// articleListResp 文章列表（分页）
+type articleListResp struct {
+	Count    int              `json:"count"`
+	PageNum  int              `json:"pageNum"`
+	PageSize int              `json:"pageSize"`
+	Results  []articleSummary `json:"results"`
+}
+
+// articleDetailResp 文章详情（摘要 + HTML 正文，目录，相关文章等），markdown 仅在请求参数 markdown=true 时返回；
+// 字段需与 OpenAPI 文档中的 ArticleDetail 一致，不直接返回 model.Article，避免内部字段成为 API 的一部分
+type articleDetailResp struct {
+	articleSummary
+	Content   string              `json:"content"`
+	Markdown  string              `json:"markdown,omitempty"`
+	Aliases   []string            `json:"aliases,omitempty"`
+	CharCount int                 `json:"charCount"`
+	TOC       []model.TOCItem     `json:"toc"`
+	Related   []model.ArticleLink `json:"related"`
+	Backlinks []model.ArticleLink `json:"backlinks"`
+	Prev      *model.ArticleLink  `json:"prev"`
+	Next      *model.ArticleLink  `json:"next"`
+	Series    *model.SeriesNav    `json:"series"`
+}
+
+func newArticleSummary(article *model.Article) articleSummary {
+	return articleSummary{
+		ID:          article.ID,
+		Category:    article.Category,
+		Tags:        article.Tags,
+		Title:       article.Title,
+		Desc:        article.Desc,
+		UpdatedAt:   article.UpdatedAt,
+		Cover:       article.Cover,
+		WordCount:   article.WordCount,
+		ReadingTime: article.ReadingTime,
+	}
+}
+
+// ListArticlesV1 获取文章列表，支持按分类 / 标签过滤，关键字搜索，排序及分页
+func ListArticlesV1(c *gin.Context) {
+	sortBy := cmp.Or(c.Query("sort"), defaultArticleSort)
+	compare, ok := articleSortFields[strings.TrimPrefix(sortBy, "-")]
+	if !ok {
+		ginx.SetErrResp(c, http.StatusBadRequest, "invalid sort "+strconv.Quote(sortBy))
+		return
+	}
+
+	articles := storage.Current().FilterArticles(c.Query("category"), c.Query("tag"))
+	if keyword := strings.TrimSpace(c.Query("q")); keyword != "" {
+		articles = lo.Filter(articles, func(article model.Article, _ int) bool {
+			return matchKeyword(&article, keyword)
+		})
+	}
+
+	// Store 返回的是副本，可以直接排序
+	if sortBy != defaultArticleSort {
+		slices.SortStableFunc(articles, func(a, b model.Article) int {
+			if strings.HasPrefix(sortBy, "-") {
+				return compare(&b, &a)
+			}
+			return compare(&a, &b)
+		})
+	}
+
+	pageNum, pageSize := ginx.GetPageNumFromQuery(c), ginx.GetPageSizeFromQuery(c)
+	results := []articleSummary{}
+	// 先判断页码是否超出总页数，超出时返回空页，避免 (pageNum-1)*pageSize 溢出
+	if pageNum-1 < (len(articles)+pageSize-1)/pageSize {
+		start := (pageNum - 1) * pageSize
+		end := min(start+pageSize, len(articles))
+		results = lo.Map(articles[start:end], func(article model.Article, _ int) articleSummary {
+			return newArticleSummary(&article)
+		})
+	}
+
+	ginx.SetResp(c, http.StatusOK, articleListResp{
+		Count:    len(articles),
+		PageNum:  pageNum,
+		PageSize: pageSize,
+		Results:  results,
+	})
+}
+
+// RetrieveArticleV1 获取文章详情（元数据，HTML 正文，目录，阅读时间等），markdown=true 时同时返回 markdown 原文
+func RetrieveArticleV1(c *gin.Context) {
+	article := storage.Current().Article(c.Param("id"))
+	if article == nil {
+		ginx.SetErrResp(c, http.StatusNotFound, "article not found")
+		return
+	}
+
+	withMarkdown, err := strconv.ParseBool(cmp.Or(c.Query("markdown"), "false"))
+	if err != nil {
+		ginx.SetErrResp(c, http.StatusBadRequest, "invalid markdown "+strconv.Quote(c.Query("markdown")))
+		return
+	}
+
+	// 是否包含 markdown 原文的响应内容不同，ETag 需要区分
+	etag := article.ETag
+	if withMarkdown {
+		etag += "-md"
+	}
+	updatedAt, _ := time.ParseInLocation(time.DateOnly, article.UpdatedAt, time.Local)
+	if ginx.CheckNotModified(c, etag, updatedAt) {
+		return
+	}
+
+	resp := articleDetailResp{
+		articleSummary: newArticleSummary(article),
+		Content:        article.Content,
+		Aliases:        article.Aliases,
+		CharCount:      article.CharCount,
+		TOC:            article.TOC,
+		Related:        article.Related,
+		Backlinks:      article.Backlinks,
+		Prev:           article.Prev,
+		Next:           article.Next,
+		Series:         article.Series,
+	}
+	if withMarkdown {
+		resp.Markdown = article.Markdown
+	}
+	ginx.SetResp(c, http.StatusOK, resp)
+}
+
+// ListCategoriesV1 获取分类列表（含文章数）
+func ListCategoriesV1(c *gin.Context) {
+	store := storage.Current()
+	if ginx.CheckNotModified(c, store.ETag(), store.LastModified()) {
+		return
+	}
+	ginx.SetResp(c, http.StatusOK, store.Categories())
+}
+
+// ListTagsV1 获取标签列表（含文章数）
+func ListTagsV1(c *gin.Context) {
+	store := storage.Current()
+	if ginx.CheckNotModified(c, store.ETag(), store.LastModified()) {
+		return
+	}
+	ginx.SetResp(c, http.StatusOK, store.Tags())
+}
+
+// ListArchivesV1 获取文章归档（按年 / 月分组，含各年月的文章数）
+func ListArchivesV1(c *gin.Context) {
+	store := storage.Current()
+	if ginx.CheckNotModified(c, store.ETag(), store.LastModified()) {
+		return
+	}
+	ginx.SetResp(c, http.StatusOK, store.Archives())
+}
+
+// RetrieveYearArchivesV1 获取某一年的文章归档
+func RetrieveYearArchivesV1(c *gin.Context) {
+	store := storage.Current()
+	year, err := archiveYearFromParam(c, store)
+	if err != nil {
+		ginx.SetErrResp(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if year == nil {
+		ginx.SetErrResp(c, http.StatusNotFound, "archive not found")
+		return
+	}
+	if ginx.CheckNotModified(c, store.ETag(), store.LastModified()) {
+		return
+	}
+	ginx.SetResp(c, http.StatusOK, year)
+}
+
+// RetrieveMonthArchivesV1 获取某年某月的文章归档
+func RetrieveMonthArchivesV1(c *gin.Context) {
+	store := storage.Current()
+	month, err := archiveMonthFromParams(c, store)
+	if err != nil {
+		ginx.SetErrResp(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if month == nil {
+		ginx.SetErrResp(c, http.StatusNotFound, "archive not found")
+		return
+	}
+	if ginx.CheckNotModified(c, store.ETag(), store.LastModified()) {
+		return
+	}
+	ginx.SetResp(c, http.StatusOK, month)
+}
+
+// GetOpenAPIDoc 获取 v1 API 的 OpenAPI 文档
+func GetOpenAPIDoc(c *gin.Context) {
+	content, err := fs.ReadFile(assets.Static(), OpenAPIDocPath)
+	if err != nil {
+		ginx.SetErrResp(c, http.StatusInternalServerError, "failed to load openapi document")
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", content)
+}
+
+// matchKeyword 文章标题，描述，分类或标签中是否包含关键字（忽略大小写）
+func matchKeyword(article *model.Article, keyword string) bool {
+	keyword = strings.ToLower(keyword)
+	return lo.ContainsBy(
+		append([]string{article.Title, article.Desc, article.Category}, article.Tags...),
+		func(s string) bool { return strings.Contains(strings.ToLower(s), keyword) },
+	)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/storage"
+	"github.com/narasux/goblog/pkg/utils/ginx"
+)
+
+// ArchivePath 归档页路径（month 为 0 表示按年归档，year 也为 0 表示归档首页）
+func ArchivePath(year, month int) string {
+	switch {
+	case year == 0:
+		return "/archives"
+	case month == 0:
+		return fmt.Sprintf("/archives/%d", year)
+	default:
+		return fmt.Sprintf("/archives/%d/%02d", year, month)
+	}
+}
+
+// GetArchives 获取归档首页（所有年份）
+func GetArchives(c *gin.Context) {
+	store := storage.Current()
+	if ginx.CheckNotModified(c, store.ETag(), store.LastModified()) {
+		return
+	}
+	renderArchives(c, store, "Archives", ArchivePath(0, 0), store.Archives())
+}
+
+// GetYearArchives 获取某一年的归档页面，没有文章时返回 404
+func GetYearArchives(c *gin.Context) {
+	store := storage.Current()
+	year, err := archiveYearFromParam(c, store)
+	if err != nil || year == nil {
+		Get404(c)
+		return
+	}
+	if ginx.CheckNotModified(c, store.ETag(), store.LastModified()) {
+		return
+	}
+	title := fmt.Sprintf("%d 年归档", year.Year)
+	renderArchives(c, store, title, ArchivePath(year.Year, 0), model.Archives{*year})
+}
+
+// GetMonthArchives 获取某年某月的归档页面，没有文章时返回 404
+func GetMonthArchives(c *gin.Context) {
+	store := storage.Current()
+	month, err := archiveMonthFromParams(c, store)
+	if err != nil || month == nil {
+		Get404(c)
+		return
+	}
+	if ginx.CheckNotModified(c, store.ETag(), store.LastModified()) {
+		return
+	}
+	title := fmt.Sprintf("%d 年 %d 月归档", month.Year, month.Month)
+	year := model.ArchiveYear{Year: month.Year, Count: month.Count, Months: []model.ArchiveMonth{*month}}
+	renderArchives(c, store, title, ArchivePath(month.Year, month.Month), model.Archives{year})
+}
+
+// renderArchives 渲染归档页面，页面顶部始终展示所有年份的导航
+func renderArchives(c *gin.Context, store *storage.Store, title, path string, archives model.Archives) {
+	c.HTML(http.StatusOK, "archives.html", map[string]any{
+		"seo":      newPageSEO(title, "", path),
+		"title":    title,
+		"years":    store.Archives(),
+		"archives": archives,
+	})
+}
+
+// archiveYearFromParam 根据路径参数 year 获取年份归档，参数不是数字时返回错误，没有文章时返回 nil
+func archiveYearFromParam(c *gin.Context, store *storage.Store) (*model.ArchiveYear, error) {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		return nil, errors.Errorf("invalid year %q", c.Param("year"))
+	}
+	return store.ArchiveYear(year), nil
+}
+
+// archiveMonthFromParams 根据路径参数 year / month 获取月份归档（月份可以不补 0），
+// 参数不是数字时返回错误，没有文章时返回 nil
+func archiveMonthFromParams(c *gin.Context, store *storage.Store) (*model.ArchiveMonth, error) {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		return nil, errors.Errorf("invalid year %q", c.Param("year"))
+	}
+	month, err := strconv.Atoi(c.Param("month"))
+	if err != nil {
+		return nil, errors.Errorf("invalid month %q", c.Param("month"))
+	}
+	return store.ArchiveMonth(year, month), nil
+}
@@ -1,17 +1,76 @@
 package handler
 
 import (
+	"encoding/xml"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
-)
 
-// Get404 获取 404 页面
-func Get404(c *gin.Context) {
-	c.HTML(http.StatusOK, "404.html", nil)
-}
+	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/storage"
+)
 
 // GetRobotsTxt 获取 robots.txt
 func GetRobotsTxt(c *gin.Context) {
-	c.String(http.StatusOK, "User-agent: *\nAllow: /\nAllow: /articles/\n\nDisallow: /static/")
+	c.String(
+		http.StatusOK,
+		"User-agent: *\nAllow: /\nAllow: /articles/\n\nDisallow: /static/\n\nSitemap: %s\n",
+		siteURL("/sitemap.xml"),
+	)
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// GetSitemap 获取 sitemap.xml
+func GetSitemap(c *gin.Context) {
+	store := storage.Current()
+	urlSet := sitemapURLSet{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	for _, path := range []string{"/", "/articles", "/categories", "/tags", "/archives", "/periodic-table"} {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{Loc: siteURL(path)})
+	}
+	for _, article := range store.Articles() {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{
+			Loc: siteURL("/articles/" + article.ID), LastMod: article.UpdatedAt,
+		})
+	}
+	for _, series := range store.SeriesList() {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{
+			Loc: siteURL("/series/" + series.ID), LastMod: series.LastModified.Format(time.DateOnly),
+		})
+	}
+	for _, category := range store.Categories() {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{Loc: siteURL(CategoryPath(category.Name))})
+	}
+	for _, tag := range store.Tags() {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{Loc: siteURL(TagPath(tag.Name))})
+	}
+	for _, year := range store.Archives() {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{
+			Loc: siteURL(ArchivePath(year.Year, 0)), LastMod: year.Months[0].LastDate(),
+		})
+		for _, month := range year.Months {
+			urlSet.URLs = append(urlSet.URLs, sitemapURL{
+				Loc: siteURL(ArchivePath(month.Year, month.Month)), LastMod: month.LastDate(),
+			})
+		}
+	}
+
+	content, _ := xml.Marshal(urlSet)
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", append([]byte(xml.Header), content...))
+}
+
+// 生成站点的完整 URL
+func siteURL(path string) string {
+	return fmt.Sprintf("%s://%s%s", envs.DomainScheme, envs.Domain, path)
 }
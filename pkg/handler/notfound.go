@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"cmp"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/storage"
+	"github.com/narasux/goblog/pkg/utils/textx"
+)
+
+// 404 页面最多推荐的文章数量
+const maxSuggestions = 5
+
+// Get404 获取 404 页面（路径匹配重定向规则时，响应 301 重定向）
+func Get404(c *gin.Context) {
+	store := storage.Current()
+	if target, ok := store.Redirects().Match(c.Request.URL.Path); ok {
+		if c.Request.URL.RawQuery != "" {
+			target += lo.Ternary(strings.Contains(target, "?"), "&", "?") + c.Request.URL.RawQuery
+		}
+		c.Redirect(http.StatusMovedPermanently, target)
+		return
+	}
+
+	var suggestions []model.ArticleLink
+	if id, ok := strings.CutPrefix(c.Request.URL.Path, "/articles/"); ok && id != "" {
+		suggestions = suggestArticles(store.Articles(), id, maxSuggestions)
+	}
+	c.HTML(http.StatusNotFound, "404.html", map[string]any{
+		"seo":         newNotFoundSEO(),
+		"suggestions": suggestions,
+	})
+}
+
+// suggestArticles 根据不存在的文章 ID 推荐可能要找的文章：
+// 优先推荐 ID 编辑距离较小的文章（拼写错误），其次是标题包含 ID 中大部分词语的文章
+func suggestArticles(articles model.Articles, id string, limit int) []model.ArticleLink {
+	if unescaped, err := url.PathUnescape(id); err == nil {
+		id = unescaped
+	}
+	id = strings.ToLower(strings.Trim(id, "/"))
+	// 允许的最大编辑距离，ID 越长允许的拼写错误越多
+	maxDistance := max(2, len([]rune(id))/3)
+	tokens := lo.Uniq(textx.Tokenize(id))
+
+	type candidate struct {
+		link     model.ArticleLink
+		distance int
+		// 标题中包含的词语比例
+		matched float64
+	}
+	var candidates []candidate
+	for _, article := range articles {
+		cand := candidate{
+			link:     model.ArticleLink{ID: article.ID, Title: article.Title},
+			distance: textx.Levenshtein(id, strings.ToLower(article.ID)),
+		}
+		if len(tokens) != 0 {
+			titleTokens := lo.Uniq(textx.Tokenize(article.Title + " " + article.ID))
+			cand.matched = float64(len(lo.Intersect(tokens, titleTokens))) / float64(len(tokens))
+		}
+		if cand.distance <= maxDistance || cand.matched >= 0.5 {
+			candidates = append(candidates, cand)
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		aClose, bClose := a.distance <= maxDistance, b.distance <= maxDistance
+		if aClose != bClose {
+			return lo.Ternary(aClose, -1, 1)
+		}
+		if aClose {
+			return cmp.Compare(a.distance, b.distance)
+		}
+		return cmp.Compare(b.matched, a.matched)
+	})
+	return lo.Map(lo.Slice(candidates, 0, limit), func(c candidate, _ int) model.ArticleLink { return c.link })
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/model"
+)
+
+func TestSuggestArticles(t *testing.T) {
+	articles := model.Articles{
+		{ID: "pipe-in-python", Title: "Python 中的管道"},
+		{ID: "golang-memory-leak-debug", Title: "Golang 内存泄漏排查"},
+		{ID: "python-security-pitfalls", Title: "Python 安全陷阱"},
+		{ID: "scaling-in-kubernetes", Title: "Scaling in Kubernetes"},
+	}
+	ids := func(links []model.ArticleLink) []string {
+		var ids []string
+		for _, link := range links {
+			ids = append(ids, link.ID)
+		}
+		return ids
+	}
+
+	// 拼写错误
+	assert.Equal(t, []string{"pipe-in-python"}, ids(suggestArticles(articles, "pipe-in-pyton", 5)))
+	// 编辑距离相近的排在标题匹配之前
+	assert.Equal(
+		t,
+		[]string{"pipe-in-python", "python-security-pitfalls"},
+		ids(suggestArticles(articles, "python-pipe", 5)),
+	)
+	// 标题搜索（支持中文及 URL 编码）
+	assert.Equal(t, []string{"golang-memory-leak-debug"}, ids(suggestArticles(articles, "%E5%86%85%E5%AD%98%E6%B3%84%E6%BC%8F", 5)))
+	// 数量限制
+	assert.Len(t, suggestArticles(articles, "python", 1), 1)
+	assert.Empty(t, suggestArticles(articles, "wontons", 5))
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/ogimage"
+	"github.com/narasux/goblog/pkg/storage"
+	"github.com/narasux/goblog/pkg/utils/ginx"
+)
+
+// 已生成的预览图（文章 ID -> ogImageCacheEntry），首次请求时生成，预览图内容变化（OGImageETag 不同）时重新生成
+var ogImageCache sync.Map
+
+type ogImageCacheEntry struct {
+	etag    string
+	content []byte
+}
+
+// GetOGImage 获取文章的社交分享预览图（/og/:id.png）
+func GetOGImage(c *gin.Context) {
+	id, ok := strings.CutSuffix(c.Param("file"), ".png")
+	article := storage.Current().Article(id)
+	if !ok || article == nil {
+		Get404(c)
+		return
+	}
+
+	updatedAt, _ := time.ParseInLocation(time.DateOnly, article.UpdatedAt, time.Local)
+	if ginx.CheckNotModified(c, article.OGImageETag, updatedAt) {
+		return
+	}
+
+	content, err := renderOGImage(article)
+	if err != nil {
+		ginx.SetError(c, err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Data(http.StatusOK, "image/png", content)
+}
+
+// 获取（或生成并缓存）文章的预览图
+func renderOGImage(article *model.Article) ([]byte, error) {
+	if entry, ok := ogImageCache.Load(article.ID); ok && entry.(ogImageCacheEntry).etag == article.OGImageETag {
+		return entry.(ogImageCacheEntry).content, nil
+	}
+
+	var buf bytes.Buffer
+	err := ogimage.Render(&buf, ogimage.Card{
+		Title:    article.Title,
+		Category: article.Category,
+		Tags:     article.Tags,
+		SiteName: siteName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ogImageCache.Store(article.ID, ogImageCacheEntry{etag: article.OGImageETag, content: buf.Bytes()})
+	return buf.Bytes(), nil
+}
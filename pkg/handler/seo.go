@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/ogimage"
+)
+
+const (
+	// 站点名称
+	siteName = "Narasux Blogs"
+	// 站点描述
+	siteDesc = "Schnee's blog, discussion about technology, thoughts and life"
+	// 作者
+	authorName = "Schnee"
+	// 默认的分享封面图（相对站点根目录）
+	defaultCoverImage = "/static/image/favicon.png"
+)
+
+// seoMeta 页面的 SEO 元数据（标题，描述，Open Graph / Twitter Card 标签，JSON-LD 等），
+// 由 common.seo 模板渲染到 <head> 中
+type seoMeta struct {
+	// SiteName og:site_name（即 siteName）
+	SiteName string
+	Title    string
+	Desc     string
+	// CanonicalURL 页面的规范链接（完整 URL），为空则不设置
+	CanonicalURL string
+	// Type og:type，如 website，article
+	Type string
+	// Image 分享封面图（完整 URL）
+	Image string
+	// ImageWidth / ImageHeight 封面图尺寸（未知时为 0）
+	ImageWidth  int
+	ImageHeight int
+	// NoIndex 禁止搜索引擎收录（如 404 页面）
+	NoIndex bool
+	// Article 文章相关的元数据（仅文章详情页）
+	Article *seoArticle
+	// JSONLD 结构化数据（schema.org），模板中会被序列化为 JSON
+	JSONLD map[string]any
+}
+
+// seoArticle 文章相关的 Open Graph 元数据
+type seoArticle struct {
+	PublishedTime string
+	ModifiedTime  string
+	Section       string
+	Tags          []string
+}
+
+// newPageSEO 生成普通页面的 SEO 元数据（title 为空表示站点首页）
+func newPageSEO(title, desc, path string) seoMeta {
+	meta := seoMeta{
+		SiteName:     siteName,
+		Title:        siteName,
+		Desc:         desc,
+		CanonicalURL: siteURL(path),
+		Type:         "website",
+		Image:        absoluteURL(defaultCoverImage),
+	}
+	if title != "" {
+		meta.Title = title + " - " + siteName
+	}
+	if meta.Desc == "" {
+		meta.Desc = siteDesc
+	}
+	return meta
+}
+
+// newArticleSEO 生成文章详情页的 SEO 元数据（含 BlogPosting JSON-LD）
+func newArticleSEO(article *model.Article) seoMeta {
+	meta := newPageSEO(article.Title, article.Desc, "/articles/"+article.ID)
+	meta.Type = "article"
+	// 优先使用文章自定义的封面图，否则使用自动生成的预览图
+	if article.Cover != "" {
+		meta.Image = absoluteURL(article.Cover)
+	} else {
+		meta.Image = siteURL("/og/" + article.ID + ".png")
+		meta.ImageWidth, meta.ImageHeight = ogimage.Width, ogimage.Height
+	}
+	meta.Article = &seoArticle{
+		// 文章只记录了更新日期，发布日期同样使用更新日期
+		PublishedTime: article.UpdatedAt,
+		ModifiedTime:  article.UpdatedAt,
+		Section:       article.Category,
+		Tags:          article.Tags,
+	}
+	meta.JSONLD = map[string]any{
+		"@context":         "https://schema.org",
+		"@type":            "BlogPosting",
+		"headline":         article.Title,
+		"description":      article.Desc,
+		"url":              meta.CanonicalURL,
+		"mainEntityOfPage": map[string]any{"@type": "WebPage", "@id": meta.CanonicalURL},
+		"image":            meta.Image,
+		"datePublished":    article.UpdatedAt,
+		"dateModified":     article.UpdatedAt,
+		"articleSection":   article.Category,
+		"keywords":         strings.Join(article.Tags, ", "),
+		"wordCount":        article.WordCount,
+		"inLanguage":       "zh-CN",
+		"author":           map[string]any{"@type": "Person", "name": authorName},
+		"publisher":        map[string]any{"@type": "Person", "name": authorName},
+	}
+	return meta
+}
+
+// newNotFoundSEO 生成 404 页面的 SEO 元数据（不设置规范链接，且禁止收录）
+func newNotFoundSEO() seoMeta {
+	return seoMeta{
+		SiteName: siteName, Title: "404 Not Found - " + siteName, Desc: siteDesc, Type: "website", NoIndex: true,
+	}
+}
+
+// absoluteURL 将站内路径转换为完整 URL（已经是完整 URL 的保持不变）
+func absoluteURL(link string) string {
+	if strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
+		return link
+	}
+	return siteURL("/" + strings.TrimPrefix(link, "/"))
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/narasux/goblog/pkg/images"
+	"github.com/narasux/goblog/pkg/storage"
+	"github.com/narasux/goblog/pkg/utils/compressx"
+	"github.com/narasux/goblog/pkg/utils/ginx"
+)
+
+// ServeStatic 静态文件服务（路由需包含 *filepath 参数），
+// 若客户端支持且存在预压缩的 .br / .gz 文件（goblog assets compress 生成），则优先返回预压缩文件
+func ServeStatic(fsys fs.FS) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := strings.TrimPrefix(path.Clean("/"+c.Param("filepath")), "/")
+
+		info, err := fs.Stat(fsys, name)
+		if err != nil || info.IsDir() {
+			Get404(c)
+			return
+		}
+
+		// 原始文件的类型，预压缩文件需要沿用
+		contentType := mime.TypeByExtension(path.Ext(name))
+
+		var candidates []string
+		for _, encoding := range compressx.Encodings {
+			if _, err = fs.Stat(fsys, name+compressx.Extension(encoding)); err == nil {
+				candidates = append(candidates, encoding)
+			}
+		}
+		if len(candidates) != 0 {
+			ginx.AddVary(c, "Accept-Encoding")
+		}
+
+		encoding := compressx.Negotiate(c.GetHeader("Accept-Encoding"), candidates)
+		serveFile(c, fsys, name, contentType, encoding)
+	}
+}
+
+// serveFile 返回文件内容（支持 Range / If-Modified-Since 等），encoding 不为空时返回对应的预压缩文件
+func serveFile(c *gin.Context, fsys fs.FS, name, contentType, encoding string) {
+	file, err := fsys.Open(name + compressx.Extension(encoding))
+	if err != nil {
+		Get404(c)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		Get404(c)
+		return
+	}
+
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		// 理论上 os.DirFS 与 embed.FS 的文件都支持 Seek
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	if contentType != "" {
+		c.Header("Content-Type", contentType)
+	}
+	if encoding != "" {
+		c.Header("Content-Encoding", encoding)
+	}
+	http.ServeContent(c.Writer, c.Request, path.Base(name), info.ModTime(), content)
+}
+
+// GetImageVariant 获取文章图片的指定宽度版本（/images/:width/*filepath，以 .webp 结尾时返回 WebP 格式）
+func GetImageVariant(c *gin.Context) {
+	width, err := strconv.Atoi(c.Param("width"))
+	if err != nil {
+		Get404(c)
+		return
+	}
+	variant, err := storage.Current().Images().Variant(strings.TrimPrefix(c.Param("filepath"), "/"), width)
+	if err != nil {
+		Get404(c)
+		return
+	}
+	if ginx.CheckNotModified(c, variant.ETag, variant.ModTime) {
+		return
+	}
+
+	content, err := variant.Content()
+	if errors.Is(err, images.ErrNotFound) {
+		Get404(c)
+		return
+	}
+	if err != nil {
+		ginx.SetError(c, err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Data(http.StatusOK, variant.ContentType, content)
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"cmp"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/storage"
+	"github.com/narasux/goblog/pkg/utils/ginx"
+)
+
+// 标签云的字号（按文章数从少到多）
+var tagCloudSizes = []string{"text-sm", "text-base", "text-lg", "text-xl", "text-2xl", "text-3xl"}
+
+// tagCloudItem 标签云中的标签
+type tagCloudItem struct {
+	model.TermCount
+	// Size 字号（tailwind 类名），文章数越多字号越大
+	Size string
+}
+
+// CategoryPath 分类页路径（加载时已确保名称中不包含 /，否则 %2F 在路由前会被解码为 /，页面无法访问）
+func CategoryPath(category string) string {
+	return "/categories/" + url.PathEscape(category)
+}
+
+// TagPath 标签页路径（同 CategoryPath，名称中不包含 /）
+func TagPath(tag string) string {
+	return "/tags/" + url.PathEscape(tag)
+}
+
+// GetCategories 获取分类列表页面（含各分类的文章数）
+func GetCategories(c *gin.Context) {
+	store := storage.Current()
+	if ginx.CheckNotModified(c, store.ETag(), store.LastModified()) {
+		return
+	}
+	c.HTML(http.StatusOK, "terms.html", map[string]any{
+		"seo":     newPageSEO("Categories", "", "/categories"),
+		"title":   "Categories",
+		"terms":   store.Categories(),
+		"pathFor": CategoryPath,
+	})
+}
+
+// GetTags 获取标签列表页面（标签云 + 各标签的文章数）
+func GetTags(c *gin.Context) {
+	store := storage.Current()
+	if ginx.CheckNotModified(c, store.ETag(), store.LastModified()) {
+		return
+	}
+	terms := store.Tags()
+	c.HTML(http.StatusOK, "terms.html", map[string]any{
+		"seo":      newPageSEO("Tags", "", "/tags"),
+		"title":    "Tags",
+		"terms":    terms,
+		"tagCloud": buildTagCloud(terms),
+		"pathFor":  TagPath,
+	})
+}
+
+// ListCategoryArticles 获取分类下的文章列表，分类不存在时返回 404
+func ListCategoryArticles(c *gin.Context) {
+	category := c.Param("name")
+	articles := storage.Current().ArticlesByCategory(category)
+	if len(articles) == 0 {
+		Get404(c)
+		return
+	}
+	renderArticles(c, "分类："+category, CategoryPath(category), articles)
+}
+
+// ListTagArticles 获取标签下的文章列表，标签不存在时返回 404
+func ListTagArticles(c *gin.Context) {
+	tag := c.Param("name")
+	articles := storage.Current().ArticlesByTag(tag)
+	if len(articles) == 0 {
+		Get404(c)
+		return
+	}
+	renderArticles(c, "标签："+tag, TagPath(tag), articles)
+}
+
+// buildTagCloud 生成标签云（按名称排序），字号按文章数在最少 ~ 最多之间线性分级
+func buildTagCloud(terms []model.TermCount) []tagCloudItem {
+	if len(terms) == 0 {
+		return nil
+	}
+	minCount := slices.MinFunc(terms, func(a, b model.TermCount) int { return a.Count - b.Count }).Count
+	maxCount := slices.MaxFunc(terms, func(a, b model.TermCount) int { return a.Count - b.Count }).Count
+
+	items := make([]tagCloudItem, 0, len(terms))
+	for _, term := range terms {
+		level := 0
+		if maxCount > minCount {
+			level = (term.Count - minCount) * (len(tagCloudSizes) - 1) / (maxCount - minCount)
+		}
+		items = append(items, tagCloudItem{TermCount: term, Size: tagCloudSizes[level]})
+	}
+	slices.SortFunc(items, func(a, b tagCloudItem) int {
+		return cmp.Or(strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), strings.Compare(a.Name, b.Name))
+	})
+	return items
+}
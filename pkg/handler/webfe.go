@@ -1,11 +1,9 @@
 package handler
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
-	"path/filepath"
+	"net/url"
 	"strings"
 	"time"
 
@@ -23,125 +21,174 @@ import (
 
 // GetHomePage 获取主页
 func GetHomePage(c *gin.Context) {
-	c.HTML(http.StatusOK, "index.html", map[string]string{
+	seo := newPageSEO("", "", "/")
+	seo.JSONLD = map[string]any{
+		"@context":    "https://schema.org",
+		"@type":       "WebSite",
+		"name":        siteName,
+		"description": siteDesc,
+		"url":         seo.CanonicalURL,
+	}
+	c.HTML(http.StatusOK, "index.html", map[string]any{
+		"seo":                        seo,
 		"googleSiteVerificationCode": envs.GoogleSiteVerificationCode,
 		"baiduSiteVerificationCode":  envs.BaiduSiteVerificationCode,
 	})
 }
 
-// ListArticles 获取文章列表
+// ListArticles 获取文章列表（仅按分类或标签过滤时，规范链接为对应的分类 / 标签页）
 func ListArticles(c *gin.Context) {
-	articles := storage.BlogData.Articles
-	if category := c.Query("category"); category != "" {
-		articles = articles.FilterByCategory(category)
-	}
-	if tag := c.Query("tag"); tag != "" {
-		articles = articles.FilterByTag(tag)
+	category, tag := c.Query("category"), c.Query("tag")
+	title, path := "Articles", "/articles"
+	switch {
+	case category != "" && tag != "":
+		title = fmt.Sprintf("分类：%s，标签：%s", category, tag)
+		path += "?category=" + url.QueryEscape(category) + "&tag=" + url.QueryEscape(tag)
+	case category != "":
+		title, path = "分类："+category, CategoryPath(category)
+	case tag != "":
+		title, path = "标签："+tag, TagPath(tag)
 	}
+	renderArticles(c, title, path, storage.Current().FilterArticles(category, tag))
+}
 
-	db := database.Client(c.Request.Context())
-
-	// 统计各个文章的点赞数量
-	type Result struct {
-		ArticleID string
-		Count     int64
-	}
-	var results []Result
-
-	// 忽略查询失败
-	db.Model(&model.ViewRecord{}).Select("article_id, count(*) as count").Group("article_id").Find(&results)
-	viewCntMap := lo.SliceToMap(results, func(item Result) (string, int64) {
-		return item.ArticleID, item.Count
-	})
-
-	db.Model(&model.LikeRecord{}).Select("article_id, count(*) as count").Group("article_id").Find(&results)
-	likeCntMap := lo.SliceToMap(results, func(item Result) (string, int64) {
-		return item.ArticleID, item.Count
-	})
+// renderArticles 渲染文章列表页面，title 同时作为页面标题
+func renderArticles(c *gin.Context, title, path string, articles model.Articles) {
+	viewCntMap, likeCntMap := countArticleRecords(c)
 
 	c.HTML(http.StatusOK, "articles.html", map[string]any{
-		"articles": articles, "viewCntMap": viewCntMap, "likeCntMap": likeCntMap,
+		"seo":         newPageSEO(title, "", path),
+		"title":       title,
+		"articles":    articles,
+		"viewCntMap":  viewCntMap,
+		"likeCntMap":  likeCntMap,
+		"interactive": database.Available(),
 	})
 }
 
 // RetrieveArticle 获取文章详情
 func RetrieveArticle(c *gin.Context) {
-	article := storage.BlogData.Articles.GetByID(c.Param("id"))
+	article := storage.Current().Article(c.Param("id"))
 	if article == nil {
 		Get404(c)
 		return
 	}
 
-	clientIP := ginx.GetClientIP(c)
-	articleID := c.Param("id")
-	db := database.Client(c.Request.Context())
-
-	// 添加文章访问记录（同一 IP 30 分钟内只统计一次）
-	var count int64
-	db.Model(&model.ViewRecord{}).Where(
-		"ip = ? AND article_id = ? AND created_at >= ?",
-		clientIP, articleID, time.Now().Add(-30*time.Minute),
-	).Count(&count)
+	addViewRecord(c, article.ID)
 
-	if count == 0 {
-		record := model.ViewRecord{
-			IP:        clientIP,
-			ArticleID: articleID,
-			BaseModel: model.BaseModel{Creator: ginx.GetClientID(c)},
-		}
-		if err := db.Create(&record).Error; err != nil {
-			// 记录失败不影响正常展示
-			logging.GetSystemLogger().Errorf("failed to create view record: %s", err.Error())
-		}
+	// 详情页还展示了相关文章，上一篇 / 下一篇等，最后修改时间取其中最新的
+	if ginx.CheckNotModified(c, article.ETag, article.LastModified) {
+		return
 	}
 
 	c.HTML(http.StatusOK, "article_detail.html", map[string]any{
+		"seo":             newArticleSEO(article),
 		"article":         article,
 		"mermaidRequired": strings.Contains(article.Content, "mermaid"),
+		"mathRequired":    strings.Contains(article.Content, `class="math `),
+		"mathJaxScript":   envs.MathJaxScriptURL,
+		"clientHighlight": envs.ClientSideHighlight,
+		"interactive":     database.Available(),
 	})
 }
 
 // GetPeriodicTable 软件设计元素周期表
 func GetPeriodicTable(c *gin.Context) {
-	logger := logging.GetSystemLogger()
-
-	content, err := os.ReadFile(filepath.Join(envs.BlogDataBaseDir, "periodic_table.json"))
-	if err != nil {
-		// 加载不到文件，也没必要报错，就提示功能开发中 :D
-		c.HTML(http.StatusOK, "coming_soon.html", nil)
-		// 打印错误日志
-		logger.Errorf("failed to load periodic table: %s", err.Error())
+	// 加载不到文件，也没必要报错，就提示功能开发中 :D
+	table, etag := storage.Current().PeriodicTable()
+	if table == nil {
+		c.HTML(http.StatusOK, "coming_soon.html", map[string]any{
+			"seo": newPageSEO("Coming soon", "", "/periodic-table"),
+		})
 		return
 	}
-
-	var periodicTable model.ElementPeriodicTable
-	if err = json.Unmarshal(content, &periodicTable); err != nil {
-		// 加载不到文件，也没必要报错，就提示功能开发中 :D
-		c.HTML(http.StatusOK, "coming_soon.html", nil)
-		// 打印错误日志
-		logger.Errorf("failed to unmarshal periodic table: %s", err.Error())
+	if ginx.CheckNotModified(c, etag, time.Time{}) {
 		return
 	}
-
-	c.HTML(http.StatusOK, "periodic_table.html", periodicTable)
+	c.HTML(http.StatusOK, "periodic_table.html", map[string]any{
+		"seo":   newPageSEO(table.Name, "", "/periodic-table"),
+		"table": table,
+	})
 }
 
 // GetRSS 获取 RSS
 func GetRSS(c *gin.Context) {
+	store := storage.Current()
+	if ginx.CheckNotModified(c, store.ETag(), store.LastModified()) {
+		return
+	}
+
 	feed := &feeds.Feed{
 		Title:       "Schnee's Blog",
-		Link:        &feeds.Link{Href: fmt.Sprintf("%s://%s/articles", envs.DomainScheme, envs.Domain)},
+		Link:        &feeds.Link{Href: siteURL("/articles")},
 		Description: "discussion about technology, thoughts and life",
 		Author:      &feeds.Author{Name: "Schnee", Email: envs.ContactEmail},
-		Updated:     time.Now(),
+		Updated:     store.LastModified(),
 	}
-	for _, article := range storage.BlogData.Articles {
+	writeFeed(c, feed, store.Articles())
+}
+
+// GetSeries 获取系列页面
+func GetSeries(c *gin.Context) {
+	store := storage.Current()
+	series := store.Series(c.Param("id"))
+	if series == nil {
+		Get404(c)
+		return
+	}
+	if ginx.CheckNotModified(c, series.ETag, series.LastModified) {
+		return
+	}
+
+	c.HTML(http.StatusOK, "series.html", map[string]any{
+		"seo":      newPageSEO(series.Title, series.Desc, "/series/"+series.ID),
+		"series":   series,
+		"articles": seriesArticles(store, series),
+	})
+}
+
+// GetSeriesRSS 获取系列的 RSS
+func GetSeriesRSS(c *gin.Context) {
+	store := storage.Current()
+	series := store.Series(c.Param("id"))
+	if series == nil {
+		Get404(c)
+		return
+	}
+	if ginx.CheckNotModified(c, series.ETag, series.LastModified) {
+		return
+	}
+
+	feed := &feeds.Feed{
+		Title:       fmt.Sprintf("Schnee's Blog - %s", series.Title),
+		Link:        &feeds.Link{Href: siteURL("/series/" + series.ID)},
+		Description: series.Desc,
+		Author:      &feeds.Author{Name: "Schnee", Email: envs.ContactEmail},
+		Updated:     series.LastModified,
+	}
+	writeFeed(c, feed, seriesArticles(store, series))
+}
+
+// 按阅读顺序获取系列中的文章
+func seriesArticles(store *storage.Store, series *model.Series) model.Articles {
+	articles := make(model.Articles, 0, len(series.ArticleIDs))
+	for _, articleID := range series.ArticleIDs {
+		if article := store.Article(articleID); article != nil {
+			articles = append(articles, *article)
+		}
+	}
+	return articles
+}
+
+// 将文章填充到 feed 中，并以 Atom 格式输出
+func writeFeed(c *gin.Context, feed *feeds.Feed, articles model.Articles) {
+	for _, article := range articles {
 		updatedAt, _ := time.ParseInLocation(time.DateOnly, article.UpdatedAt, time.Local)
 		feed.Items = append(feed.Items, &feeds.Item{
 			Id:          article.ID,
 			Title:       article.Title,
-			Link:        &feeds.Link{Href: fmt.Sprintf("%s://%s/articles/%s", envs.DomainScheme, envs.Domain, article.ID)},
-			Description: article.Desc,
+			Link:        &feeds.Link{Href: siteURL("/articles/" + article.ID)},
+			Description: fmt.Sprintf("%s（约 %d 字，阅读需 %d 分钟）", article.Desc, article.WordCount, article.ReadingTime),
 			Author:      &feeds.Author{Name: "Schnee", Email: envs.ContactEmail},
 			Created:     updatedAt,
 			Updated:     updatedAt,
@@ -154,3 +201,57 @@ func GetRSS(c *gin.Context) {
 	c.Writer.WriteHeader(http.StatusOK)
 	_, _ = c.Writer.Write([]byte(atom))
 }
+
+// 统计各个文章的阅读 & 点赞数量（数据库不可用时返回空）
+func countArticleRecords(c *gin.Context) (viewCntMap, likeCntMap map[string]int64) {
+	if !database.Available() {
+		return nil, nil
+	}
+	db := database.Client(c.Request.Context())
+
+	type Result struct {
+		ArticleID string
+		Count     int64
+	}
+	var results []Result
+
+	// 忽略查询失败
+	db.Model(&model.ViewRecord{}).Select("article_id, count(*) as count").Group("article_id").Find(&results)
+	viewCntMap = lo.SliceToMap(results, func(item Result) (string, int64) {
+		return item.ArticleID, item.Count
+	})
+
+	db.Model(&model.LikeRecord{}).Select("article_id, count(*) as count").Group("article_id").Find(&results)
+	likeCntMap = lo.SliceToMap(results, func(item Result) (string, int64) {
+		return item.ArticleID, item.Count
+	})
+	return viewCntMap, likeCntMap
+}
+
+// 添加文章访问记录（同一 IP 30 分钟内只统计一次，数据库不可用时跳过）
+func addViewRecord(c *gin.Context, articleID string) {
+	if !database.Available() {
+		return
+	}
+	clientIP := ginx.GetClientIP(c)
+	db := database.Client(c.Request.Context())
+
+	var count int64
+	db.Model(&model.ViewRecord{}).Where(
+		"ip = ? AND article_id = ? AND created_at >= ?",
+		clientIP, articleID, time.Now().Add(-30*time.Minute),
+	).Count(&count)
+
+	if count != 0 {
+		return
+	}
+	record := model.ViewRecord{
+		IP:        clientIP,
+		ArticleID: articleID,
+		BaseModel: model.BaseModel{Creator: ginx.GetClientID(c)},
+	}
+	if err := db.Create(&record).Error; err != nil {
+		// 记录失败不影响正常展示
+		logging.GetSystemLogger().Errorf("failed to create view record: %s", err.Error())
+	}
+}
@@ -0,0 +1,306 @@
+// Package images 文章图片的响应式处理：读取图片尺寸，按需生成不同宽度（及 WebP 格式）的版本，并缓存到磁盘
+package images
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/pkg/errors"
+	"golang.org/x/image/draw"
+	"golang.org/x/sync/singleflight"
+
+	// 注册图片解码器
+	_ "image/gif"
+
+	_ "golang.org/x/image/webp"
+
+	"github.com/narasux/goblog/pkg/assets"
+	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/utils/markdownx"
+)
+
+const (
+	// RoutePrefix 图片版本的访问路径前缀，完整路径为 /images/<宽度>/<静态文件路径>[.webp]
+	RoutePrefix = "/images/"
+	// 静态文件的访问路径前缀
+	staticPrefix = "/static/"
+	// WebP 版本的后缀（追加到原文件名之后，如 a.png.webp）
+	webpExt = ".webp"
+	// JPEG 编码质量
+	jpegQuality = 85
+)
+
+// Widths 生成的图片宽度（仅生成比原图窄的版本）
+var Widths = []int{480, 960, 1600}
+
+// ErrNotFound 图片或图片版本不存在
+var ErrNotFound = errors.New("image not found")
+
+// Registry 已解析的图片（静态文件路径 -> resolvedImage），用于生成版本时校验及静态导出时枚举；
+// 随博客数据一起加载，替换博客数据时一并替换，不会残留已删除的图片
+type Registry struct {
+	images sync.Map
+	// 同一版本的并发请求只生成一次
+	group singleflight.Group
+}
+
+// NewRegistry ...
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+type resolvedImage struct {
+	config image.Config
+	// 图片格式，如 png，jpeg
+	format string
+	// 原图内容哈希（用于缓存文件名及 ETag，原图变化后自动失效）
+	hash string
+	// 原图的修改时间（嵌入二进制的静态文件为零值）
+	modTime time.Time
+}
+
+// Resolve 解析文章中的图片地址，仅处理站内的静态图片（其余返回 nil），图片不存在或无法解析时返回错误
+func (r *Registry) Resolve(src string) (*markdownx.ImageInfo, error) {
+	name, ok := staticName(src)
+	if !ok {
+		return nil, nil
+	}
+	if value, ok := r.images.Load(name); ok {
+		return value.(resolvedImage).info(name), nil
+	}
+
+	content, err := fs.ReadFile(assets.Static(), name)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, src)
+	}
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(content))
+	// SVG 等无法解析尺寸的格式不做处理
+	if errors.Is(err, image.ErrFormat) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode image %s", src)
+	}
+
+	sum := sha256.Sum256(content)
+	img := resolvedImage{config: cfg, format: format, hash: hex.EncodeToString(sum[:])[:16]}
+	if stat, err := fs.Stat(assets.Static(), name); err == nil {
+		img.modTime = stat.ModTime()
+	}
+	r.images.Store(name, img)
+	return img.info(name), nil
+}
+
+// info 生成图片信息（各尺寸版本的访问路径）
+func (img resolvedImage) info(name string) *markdownx.ImageInfo {
+	src, width := staticPrefix+name, img.config.Width
+	info := &markdownx.ImageInfo{Width: width, Height: img.config.Height}
+	// GIF 可能是动图，不生成其他版本
+	if img.format != "png" && img.format != "jpeg" {
+		info.SrcSet = []markdownx.ImageSource{{URL: src, Width: width}}
+		return info
+	}
+	for _, w := range variantWidths(width) {
+		info.SrcSet = append(info.SrcSet, markdownx.ImageSource{URL: VariantURL(name, w, false), Width: w})
+	}
+	info.SrcSet = append(info.SrcSet, markdownx.ImageSource{URL: src, Width: width})
+
+	// 仅支持无损 WebP 编码，照片（JPEG）转为无损 WebP 后通常更大，因此只为 PNG 生成 WebP 版本
+	if img.format == "png" {
+		for _, w := range append(variantWidths(width), width) {
+			info.WebPSrcSet = append(info.WebPSrcSet, markdownx.ImageSource{URL: VariantURL(name, w, true), Width: w})
+		}
+	}
+	return info
+}
+
+// VariantURL 图片版本的访问路径，如 /images/480/image/blog/a.png.webp
+func VariantURL(name string, width int, webp bool) string {
+	url := RoutePrefix + strconv.Itoa(width) + "/" + name
+	if webp {
+		url += webpExt
+	}
+	return url
+}
+
+// VariantURLs 所有已解析图片的版本访问路径（用于静态导出）
+func (r *Registry) VariantURLs() []string {
+	var urls []string
+	r.images.Range(func(key, value any) bool {
+		info := value.(resolvedImage).info(key.(string))
+		for _, source := range append(info.SrcSet, info.WebPSrcSet...) {
+			if strings.HasPrefix(source.URL, RoutePrefix) {
+				urls = append(urls, source.URL)
+			}
+		}
+		return true
+	})
+	slices.Sort(urls)
+	return urls
+}
+
+// Variant 图片版本，内容通过 Content 按需读取 / 生成，可先根据 ETag / ModTime 判断客户端缓存是否有效
+type Variant struct {
+	// ContentType 版本的格式，如 image/png，image/webp
+	ContentType string
+	// ETag 版本的内容标识（原图内容哈希 + 宽度 + 格式）
+	ETag string
+	// ModTime 原图的修改时间（嵌入二进制的静态文件为零值）
+	ModTime time.Time
+
+	registry  *Registry
+	name      string
+	width     int
+	webp      bool
+	cacheFile string
+}
+
+// Variant 获取图片版本，file 为访问路径中宽度之后的部分，以 .webp 结尾（且原图不是该文件）时表示 WebP 版本；
+// 只允许 Resolve 中生成的版本，避免被用于生成任意尺寸 / 格式的图片
+func (r *Registry) Variant(file string, width int) (*Variant, error) {
+	name, webp := file, false
+	value, ok := r.images.Load(name)
+	if !ok {
+		// 原图文件名本身也可能以 .webp 结尾，因此优先按原图匹配
+		if name, webp = strings.CutSuffix(file, webpExt); webp {
+			value, ok = r.images.Load(name)
+		}
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+	img := value.(resolvedImage)
+	info, url := img.info(name), VariantURL(name, width, webp)
+	if !slices.ContainsFunc(append(info.SrcSet, info.WebPSrcSet...), func(s markdownx.ImageSource) bool {
+		return s.URL == url
+	}) {
+		return nil, ErrNotFound
+	}
+
+	// 按图片实际格式（而非文件扩展名）确定版本格式
+	ext := "." + img.format
+	if webp {
+		ext = webpExt
+	}
+	key := fmt.Sprintf("%s-%d%s", img.hash, width, ext)
+	return &Variant{
+		ContentType: "image/" + strings.TrimPrefix(ext, "."),
+		ETag:        key,
+		ModTime:     img.modTime,
+		registry:    r,
+		name:        name,
+		width:       width,
+		webp:        webp,
+		cacheFile:   filepath.Join(envs.ImageCacheDir, key),
+	}, nil
+}
+
+// Content 获取版本内容（优先读取磁盘缓存，否则生成并写入缓存）
+func (v *Variant) Content() ([]byte, error) {
+	if cached, err := os.ReadFile(v.cacheFile); err == nil {
+		return cached, nil
+	}
+
+	content, err, _ := v.registry.group.Do(v.cacheFile, func() (any, error) {
+		// 可能刚由其他请求生成完成
+		if cached, err := os.ReadFile(v.cacheFile); err == nil {
+			return cached, nil
+		}
+		original, err := fs.ReadFile(assets.Static(), v.name)
+		if err != nil {
+			return nil, ErrNotFound
+		}
+		variant, err := resize(original, v.width, v.webp)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resize image %s", v.name)
+		}
+		if err = writeCache(v.cacheFile, variant); err != nil {
+			return nil, err
+		}
+		return variant, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return content.([]byte), nil
+}
+
+// resize 将图片缩放到指定宽度（等比例），编码为原格式或 WebP
+func resize(content []byte, width int, webp bool) ([]byte, error) {
+	src, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	bounds := src.Bounds()
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+
+	var buf bytes.Buffer
+	switch {
+	case webp:
+		err = nativewebp.Encode(&buf, dst, nil)
+	case format == "jpeg":
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality})
+	default:
+		err = (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(&buf, dst)
+	}
+	return buf.Bytes(), err
+}
+
+// 写入缓存文件（先写临时文件再重命名，避免并发请求读到不完整的文件）
+func writeCache(cacheFile string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(cacheFile), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), cacheFile)
+}
+
+// 比原图窄的预设宽度
+func variantWidths(originalWidth int) []int {
+	var widths []int
+	for _, width := range Widths {
+		if width < originalWidth {
+			widths = append(widths, width)
+		}
+	}
+	return widths
+}
+
+// 图片地址对应的静态文件路径（相对静态文件目录），非站内静态文件返回 false
+func staticName(src string) (string, bool) {
+	src, _, _ = strings.Cut(src, "?")
+	if !strings.HasPrefix(src, staticPrefix) {
+		return "", false
+	}
+	name := path.Clean(strings.TrimPrefix(src, staticPrefix))
+	return name, name != "." && !strings.HasPrefix(name, "..")
+}
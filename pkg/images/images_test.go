@@ -0,0 +1,136 @@
+package images_test
+
+import (
+	"bytes"
+	"image"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/images"
+	"github.com/narasux/goblog/pkg/utils/markdownx"
+
+	_ "golang.org/x/image/webp"
+)
+
+func TestResolve(t *testing.T) {
+	registry := images.NewRegistry()
+	// 站外图片及无法解析尺寸的图片不做处理
+	for _, src := range []string{"https://example.com/a.png", "/static/image/blog/vcluster_networking.svg"} {
+		info, err := registry.Resolve(src)
+		assert.Nil(t, err)
+		assert.Nil(t, info)
+	}
+
+	_, err := registry.Resolve("/static/image/blog/not-exists.png")
+	assert.True(t, errors.Is(err, images.ErrNotFound))
+
+	info, err := registry.Resolve("/static/image/blog/which_middleware_cause_302.png")
+	assert.Nil(t, err)
+	assert.Equal(t, 886, info.Width)
+	assert.Equal(t, 102, info.Height)
+	assert.Equal(t, []markdownx.ImageSource{
+		{URL: "/images/480/image/blog/which_middleware_cause_302.png", Width: 480},
+		{URL: "/static/image/blog/which_middleware_cause_302.png", Width: 886},
+	}, info.SrcSet)
+	assert.Equal(t, []markdownx.ImageSource{
+		{URL: "/images/480/image/blog/which_middleware_cause_302.png.webp", Width: 480},
+		{URL: "/images/886/image/blog/which_middleware_cause_302.png.webp", Width: 886},
+	}, info.WebPSrcSet)
+	assert.Contains(t, registry.VariantURLs(), "/images/480/image/blog/which_middleware_cause_302.png")
+
+	// 重复解析同一图片
+	again, err := registry.Resolve("/static/image/blog/which_middleware_cause_302.png?v=1")
+	assert.Nil(t, err)
+	assert.Equal(t, info, again)
+
+	// 各 Registry 相互独立（替换博客数据后不会残留旧的图片）
+	assert.Empty(t, images.NewRegistry().VariantURLs())
+}
+
+func TestVariant(t *testing.T) {
+	envs.ImageCacheDir = t.TempDir()
+	registry := images.NewRegistry()
+	_, err := registry.Resolve("/static/image/blog/which_middleware_cause_302.png")
+	assert.Nil(t, err)
+
+	etags := map[string]bool{}
+	for _, webp := range []bool{false, true} {
+		file := "image/blog/which_middleware_cause_302.png"
+		if webp {
+			file += ".webp"
+		}
+		variant, err := registry.Variant(file, 480)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, variant.ETag)
+		etags[variant.ETag] = true
+
+		// 第二次读取的是磁盘缓存
+		for range 2 {
+			content, err := variant.Content()
+			assert.Nil(t, err)
+
+			cfg, format, err := image.DecodeConfig(bytes.NewReader(content))
+			assert.Nil(t, err)
+			assert.Equal(t, "image/"+format, variant.ContentType)
+			assert.Equal(t, 480, cfg.Width)
+			assert.Equal(t, 55, cfg.Height)
+		}
+	}
+	// 不同格式的版本 ETag 不同
+	assert.Len(t, etags, 2)
+
+	// 未预设的宽度 / 未解析的图片
+	_, err = registry.Variant("image/blog/which_middleware_cause_302.png", 960)
+	assert.True(t, errors.Is(err, images.ErrNotFound))
+	_, err = registry.Variant("image/blog/dominoes.png", 480)
+	assert.True(t, errors.Is(err, images.ErrNotFound))
+	_, err = images.NewRegistry().Variant("image/blog/which_middleware_cause_302.png", 480)
+	assert.True(t, errors.Is(err, images.ErrNotFound))
+}
+
+func TestVariantConcurrent(t *testing.T) {
+	envs.ImageCacheDir = t.TempDir()
+	registry := images.NewRegistry()
+	_, err := registry.Resolve("/static/image/blog/which_middleware_cause_302.png")
+	assert.Nil(t, err)
+
+	// 并发请求同一个未缓存的版本，只生成一次，所有请求得到相同的内容
+	contents := make([][]byte, 8)
+	var wg sync.WaitGroup
+	for i := range contents {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			variant, err := registry.Variant("image/blog/which_middleware_cause_302.png.webp", 480)
+			assert.Nil(t, err)
+			contents[i], err = variant.Content()
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+	for _, content := range contents {
+		assert.NotEmpty(t, content)
+		assert.Equal(t, contents[0], content)
+	}
+}
+
+func TestVariantWebPExtension(t *testing.T) {
+	envs.ImageCacheDir = t.TempDir()
+	registry := images.NewRegistry()
+	// 文件名以 .webp 结尾，但实际是 PNG 格式
+	info, err := registry.Resolve("/static/image/blog/keda_workflow.webp")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, info.WebPSrcSet)
+
+	variant, err := registry.Variant("image/blog/keda_workflow.webp", 480)
+	assert.Nil(t, err)
+	assert.Equal(t, "image/png", variant.ContentType)
+
+	variant, err = registry.Variant("image/blog/keda_workflow.webp.webp", 480)
+	assert.Nil(t, err)
+	assert.Equal(t, "image/webp", variant.ContentType)
+}
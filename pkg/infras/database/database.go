@@ -41,6 +41,11 @@ func Client(ctx context.Context) *gorm.DB {
 	return db.WithContext(ctx)
 }
 
+// Available 数据库客户端是否已初始化（静态导出等场景下不连接数据库，依赖数据库的功能需降级）
+func Available() bool {
+	return db != nil
+}
+
 // InitDBClient 初始化数据库客户端
 func InitDBClient(ctx context.Context) {
 	if db != nil {
@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/logging"
+)
+
+var (
+	rds         *redis.Client
+	rdsInitOnce sync.Once
+)
+
+const (
+	// 默认连接池大小
+	defaultPoolSize = 20
+	// 默认最小空闲连接
+	defaultMinIdleConns = 5
+	// 默认连接超时时间
+	defaultDialTimeout = 3 * time.Second
+	// 默认读写超时时间
+	defaultReadWriteTimeout = time.Second
+)
+
+// Client 获取 Redis 客户端
+func Client() *redis.Client {
+	if rds == nil {
+		log.Fatal("redis client not init")
+	}
+	return rds
+}
+
+// InitRedisClient 初始化 Redis 客户端
+func InitRedisClient(ctx context.Context) {
+	if rds != nil {
+		return
+	}
+	rdsInitOnce.Do(func() {
+		rdsInfo := fmt.Sprintf("redis %s/%d", envs.RedisAddr, envs.RedisDB)
+
+		var err error
+		if rds, err = newClient(ctx); err != nil {
+			log.Fatalf("failed to connect %s: %s", rdsInfo, err)
+		} else {
+			logging.GetSystemLogger().Infof("%s connected", rdsInfo)
+		}
+	})
+}
+
+// 初始化 Redis Client
+func newClient(ctx context.Context) (*redis.Client, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:         envs.RedisAddr,
+		Password:     envs.RedisPassword,
+		DB:           envs.RedisDB,
+		PoolSize:     defaultPoolSize,
+		MinIdleConns: defaultMinIdleConns,
+		DialTimeout:  defaultDialTimeout,
+		ReadTimeout:  defaultReadWriteTimeout,
+		WriteTimeout: defaultReadWriteTimeout,
+	})
+
+	cCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// 检查 Redis 是否可用
+	if err := client.Ping(cCtx).Err(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
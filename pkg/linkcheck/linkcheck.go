@@ -0,0 +1,123 @@
+// Package linkcheck 离线检查文章中的站外链接，只根据链接格式发现明显有误的链接（不发起网络请求）
+package linkcheck
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/narasux/goblog/pkg/utils/markdownx"
+)
+
+// 链接首尾常见的误输入标点（如多写了一层括号，或自动链接把紧跟其后的中文标点也识别为链接的一部分）
+const (
+	leadingPunctuation  = "([<'\"（【「『《"
+	trailingPunctuation = ".,;:!?'\"，。；：！？、）】」』》"
+)
+
+// Problem 有问题的链接
+type Problem struct {
+	// File 链接所在的文件
+	File string
+	// Link 链接地址
+	Link string
+	// Reason 问题描述
+	Reason string
+}
+
+// IsExternal 是否为站外链接（带协议或以 // 开头）
+func IsExternal(link string) bool {
+	if strings.HasPrefix(link, "//") {
+		return true
+	}
+	scheme, _, ok := strings.Cut(link, ":")
+	return ok && scheme != "" && !strings.ContainsAny(scheme, "/?#")
+}
+
+// Check 检查站外链接的格式，返回问题描述，格式没有问题时返回空字符串
+func Check(link string) string {
+	if strings.IndexFunc(link, unicode.IsSpace) != -1 {
+		return "contains whitespace"
+	}
+	if first, _ := utf8.DecodeRuneInString(link); strings.ContainsRune(leadingPunctuation, first) {
+		return fmt.Sprintf("starts with punctuation %q", first)
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return fmt.Sprintf("invalid url: %s", unwrap(err))
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "mailto":
+		if !strings.Contains(u.Opaque, "@") {
+			return "invalid email address"
+		}
+		return ""
+	case "http", "https":
+	case "":
+		// 协议相对链接（//example.com）
+	default:
+		return fmt.Sprintf("unsupported scheme %s", u.Scheme)
+	}
+
+	if reason := checkHost(u.Hostname()); reason != "" {
+		return reason
+	}
+	if last, _ := utf8.DecodeLastRuneInString(link); strings.ContainsRune(trailingPunctuation, last) {
+		return fmt.Sprintf("ends with punctuation %q", last)
+	}
+	if strings.Count(link, "(") != strings.Count(link, ")") {
+		return "unbalanced parentheses"
+	}
+	return ""
+}
+
+// CheckMarkdown 检查 markdown 文档中所有站外链接的格式，file 仅用于标识问题所在的文件
+func CheckMarkdown(file string, content []byte) []Problem {
+	var problems []Problem
+	for _, link := range markdownx.Links(markdownx.Parse(content)) {
+		if !IsExternal(link) {
+			continue
+		}
+		if reason := Check(link); reason != "" {
+			problems = append(problems, Problem{File: file, Link: link, Reason: reason})
+		}
+	}
+	return problems
+}
+
+// checkHost 检查域名 / IP 的格式
+func checkHost(host string) string {
+	switch {
+	case host == "":
+		return "missing host"
+	case host == "localhost":
+		// 文章中的示例地址
+		return ""
+	case !strings.Contains(host, "."):
+		// 如 https://https://example.com 被解析为域名 https
+		return fmt.Sprintf("host %s has no top-level domain", host)
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Sprintf("invalid host %s", host)
+		}
+		for _, r := range label {
+			// 允许国际化域名中的字母（如中文域名），但不允许标点符号
+			if r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return fmt.Sprintf("invalid character %q in host %s", r, host)
+			}
+		}
+	}
+	return ""
+}
+
+// unwrap 去掉 url.Error 中重复的操作及链接信息
+func unwrap(err error) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		return urlErr.Err
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package linkcheck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/linkcheck"
+)
+
+func TestIsExternal(t *testing.T) {
+	for link, expected := range map[string]bool{
+		"https://example.com":  true,
+		"//example.com/a":      true,
+		"mailto:a@example.com": true,
+		"/articles/a":          false,
+		"#anchor":              false,
+		"a/b:c":                false,
+		"?a=b:c":               false,
+	} {
+		assert.Equal(t, expected, linkcheck.IsExternal(link), link)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, link := range []string{
+		"https://example.com",
+		"http://localhost:8080/a",
+		"https://127.0.0.1:8080",
+		"https://中文.com/路径",
+		"https://en.wikipedia.org/wiki/Go_(programming_language)",
+		"//cdn.example.com/a.js",
+		"mailto:a@example.com",
+	} {
+		assert.Empty(t, linkcheck.Check(link), link)
+	}
+
+	for link, expected := range map[string]string{
+		"https://example.com/a b":     "contains whitespace",
+		"(https://example.com)":       `starts with punctuation '('`,
+		"ftp://example.com":           "unsupported scheme ftp",
+		"mailto:someone":              "invalid email address",
+		"https:///a":                  "missing host",
+		"https://https://example.com": "host https has no top-level domain",
+		"https://example..com":        "invalid host example..com",
+		"https://-example.com":        "invalid host -example.com",
+		"https://example.com，更多":      `invalid character '，' in host example.com，更多`,
+		"https://example.com/a，":      `ends with punctuation '，'`,
+		"https://example.com/a(b":     "unbalanced parentheses",
+		"https://example.com/%zz":     `invalid url: invalid URL escape "%zz"`,
+		"https://example.com:port/a":  `invalid url: invalid port ":port" after host`,
+	} {
+		assert.Equal(t, expected, linkcheck.Check(link), link)
+	}
+}
+
+func TestCheckMarkdown(t *testing.T) {
+	content := "[a](https://example.com) [b]((https://example.com)) [c](/articles/a)\n\n" +
+		"```\n[d](https://https://example.com)\n```\n\n<a href=\"https://https://example.com\">e</a>\n"
+	assert.Equal(t, []linkcheck.Problem{
+		{File: "a.md", Link: "(https://example.com)", Reason: `starts with punctuation '('`},
+		{File: "a.md", Link: "https://https://example.com", Reason: "host https has no top-level domain"},
+	}, linkcheck.CheckMarkdown("a.md", []byte(content)))
+}
@@ -0,0 +1,49 @@
+package loader
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/narasux/goblog/pkg/model"
+)
+
+// 按更新日期归档文章
+func (l *BlogLoader) collectArchives() (err error) {
+	l.blogData.Archives, err = buildArchives(l.blogData.Articles)
+	return err
+}
+
+// buildArchives 按年 / 月归档文章（年月及文章均按日期降序，同一天的按 ID 排序），日期格式有误时返回错误
+func buildArchives(articles model.Articles) (model.Archives, error) {
+	sorted := slices.Clone(articles)
+	slices.SortStableFunc(sorted, func(a, b model.Article) int {
+		return cmp.Or(cmp.Compare(b.UpdatedAt, a.UpdatedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	var archives model.Archives
+	for _, article := range sorted {
+		date, err := time.Parse(time.DateOnly, article.UpdatedAt)
+		if err != nil {
+			return nil, errors.Errorf("article %s has invalid date %q, expected format is YYYY-MM-DD", article.ID, article.UpdatedAt)
+		}
+
+		if len(archives) == 0 || archives[len(archives)-1].Year != date.Year() {
+			archives = append(archives, model.ArchiveYear{Year: date.Year()})
+		}
+		year := &archives[len(archives)-1]
+		if len(year.Months) == 0 || year.Months[len(year.Months)-1].Month != int(date.Month()) {
+			year.Months = append(year.Months, model.ArchiveMonth{Year: date.Year(), Month: int(date.Month())})
+		}
+		month := &year.Months[len(year.Months)-1]
+
+		month.Articles = append(month.Articles, model.ArchiveArticle{
+			ID: article.ID, Title: article.Title, Date: article.UpdatedAt,
+		})
+		month.Count++
+		year.Count++
+	}
+	return archives, nil
+}
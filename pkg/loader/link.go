@@ -0,0 +1,100 @@
+package loader
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/TencentBlueKing/gopkg/collection/set"
+	"github.com/pkg/errors"
+
+	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/logging"
+	"github.com/narasux/goblog/pkg/model"
+)
+
+// 检查文章间的站内链接（文章 / 标题锚点是否存在），并生成反向链接（被以下文章引用）
+func (l *BlogLoader) checkLinks() error {
+	backlinks, problems := checkArticleLinks(
+		l.blogData.Articles, l.blogData.Redirects, l.articleLinks, l.articleAnchors,
+	)
+	for idx, article := range l.blogData.Articles {
+		l.blogData.Articles[idx].Backlinks = backlinks[article.ID]
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	if envs.StrictLinkCheck {
+		return errors.Errorf("broken internal links:\n%s", strings.Join(problems, "\n"))
+	}
+	for _, problem := range problems {
+		logging.GetSystemLogger().Warnf("broken internal link: %s", problem)
+	}
+	return nil
+}
+
+// checkArticleLinks 检查各文章中的站内链接，返回反向链接（文章 ID -> 引用该文章的文章）及失效链接的描述
+//
+// links 为各文章中的链接地址，anchors 为各文章中的标题锚点；指向文章别名 / 重定向规则的链接按重定向后的文章检查
+func checkArticleLinks(
+	articles model.Articles, redirects model.Redirects, links map[string][]string, anchors map[string]*set.StringSet,
+) (map[string][]model.ArticleLink, []string) {
+	articleIDs := set.NewStringSet()
+	for _, article := range articles {
+		articleIDs.Add(article.ID)
+	}
+
+	backlinks := map[string][]model.ArticleLink{}
+	var problems []string
+	for _, article := range articles {
+		// 同一篇文章多次引用时，只记录一次反向链接
+		referenced := set.NewStringSet()
+		for _, link := range links[article.ID] {
+			targetID, anchor, ok := internalArticleLink(link, article.ID, redirects)
+			if !ok {
+				continue
+			}
+			source := fmt.Sprintf("articles/%s.md", article.ID)
+			if !articleIDs.Has(targetID) {
+				problems = append(problems, fmt.Sprintf("%s: link %s points to unknown article %s", source, link, targetID))
+				continue
+			}
+			if anchor != "" && (anchors[targetID] == nil || !anchors[targetID].Has(anchor)) {
+				problems = append(problems, fmt.Sprintf("%s: link %s points to missing heading #%s", source, link, anchor))
+			}
+			if targetID != article.ID && !referenced.Has(targetID) {
+				referenced.Add(targetID)
+				backlinks[targetID] = append(backlinks[targetID], model.ArticleLink{ID: article.ID, Title: article.Title})
+			}
+		}
+	}
+	return backlinks, problems
+}
+
+// internalArticleLink 解析指向站内文章的链接，返回目标文章 ID 及锚点，非站内文章链接返回 false
+func internalArticleLink(link, currentID string, redirects model.Redirects) (articleID, anchor string, ok bool) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", "", false
+	}
+	// 完整 URL 仅当域名为本站时才是站内链接
+	if u.Scheme != "" || u.Host != "" {
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host != envs.Domain {
+			return "", "", false
+		}
+	}
+	// 仅包含锚点，指向当前文章
+	if u.Path == "" {
+		return currentID, u.Fragment, u.Fragment != ""
+	}
+
+	p := strings.TrimSuffix(u.Path, "/")
+	if to, matched := redirects.Match(p); matched {
+		p = to
+	}
+	id, ok := strings.CutPrefix(p, articlePathPrefix)
+	if !ok || id == "" || strings.Contains(id, "/") {
+		return "", "", false
+	}
+	return id, u.Fragment, true
+}
@@ -1,33 +1,68 @@
 package loader
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"os"
-	"path/filepath"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/TencentBlueKing/gopkg/collection/set"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
 
+	"github.com/narasux/goblog/pkg/assets"
 	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/images"
+	"github.com/narasux/goblog/pkg/logging"
 	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/shortcode"
 	"github.com/narasux/goblog/pkg/utils/markdownx"
+	"github.com/narasux/goblog/pkg/utils/textx"
+	"github.com/narasux/goblog/pkg/version"
 )
 
 // BlogLoader 博客文章加载器
 type BlogLoader struct {
 	blogData model.BlogData
+	// 文章中已解析的图片（用于生成各尺寸版本）
+	images *images.Registry
+	// 文章正文纯文本（文章 ID -> 纯文本），用于计算相关文章
+	articleTexts map[string]string
+	// 文章中的链接（文章 ID -> 链接地址）及标题锚点（文章 ID -> 锚点），用于检查站内链接
+	articleLinks   map[string][]string
+	articleAnchors map[string]*set.StringSet
 }
 
 // New ...
 func New() *BlogLoader {
-	return &BlogLoader{blogData: model.BlogData{}}
+	return &BlogLoader{
+		images:         images.NewRegistry(),
+		articleTexts:   map[string]string{},
+		articleLinks:   map[string][]string{},
+		articleAnchors: map[string]*set.StringSet{},
+	}
 }
 
 func (l *BlogLoader) Exec() (*model.BlogData, error) {
 	for _, f := range []func() error{
 		l.loadArticleMetadata,
+		l.loadRedirects,
+		// 文章中的短代码可能引用元素周期表，需要在文章内容之前加载
+		l.loadPeriodicTable,
 		l.loadArticleContent,
+		l.checkLinks,
 		l.collectCategories,
 		l.collectTags,
+		l.collectArchives,
+		l.computeRelatedArticles,
+		l.loadSeries,
+		l.computeCacheValidators,
 	} {
 		if err := f(); err != nil {
 			return nil, err
@@ -36,9 +71,14 @@ func (l *BlogLoader) Exec() (*model.BlogData, error) {
 	return &l.blogData, nil
 }
 
+// Images 加载文章时解析的图片，与 Exec 返回的博客数据一同交给 storage.Store
+func (l *BlogLoader) Images() *images.Registry {
+	return l.images
+}
+
 // 加载博客文章元数据
 func (l *BlogLoader) loadArticleMetadata() error {
-	content, err := os.ReadFile(filepath.Join(envs.BlogDataBaseDir, "articles.json"))
+	content, err := fs.ReadFile(assets.Data(), "articles.json")
 	if err != nil {
 		return err
 	}
@@ -49,34 +89,229 @@ func (l *BlogLoader) loadArticleMetadata() error {
 	return nil
 }
 
-// 加载博客文章内容
+// 加载博客文章内容（同时展开短代码，根据标题生成目录，统计字数）
 func (l *BlogLoader) loadArticleContent() error {
+	icons, err := admonitionIcons()
+	if err != nil {
+		return err
+	}
+	renderer := markdownx.NewRenderer(markdownx.DefaultClassMap).WithAdmonitionIcons(icons)
+	shortcodes := shortcode.Shortcodes(&l.blogData)
+
 	for idx, article := range l.blogData.Articles {
-		content, err := os.ReadFile(filepath.Join(envs.BlogDataBaseDir, "articles", article.ID+".md"))
+		file := path.Join("articles", article.ID+".md")
+		content, err := fs.ReadFile(assets.Data(), file)
 		if err != nil {
 			return err
 		}
-		l.blogData.Articles[idx].Content = markdownx.ToHTML(content)
+		l.blogData.Articles[idx].Markdown = string(content)
+		if content, err = markdownx.ExpandShortcodes(file, content, shortcodes); err != nil {
+			return err
+		}
+		doc := markdownx.Parse(content)
+		l.blogData.Articles[idx].Content = renderer.WithImageResolver(l.imageResolver(article.ID)).Render(doc)
+		// 标题 ID 在渲染时去重，因此需要在渲染后再生成目录
+		headings := markdownx.Headings(doc)
+		l.blogData.Articles[idx].TOC = buildTOC(headings)
+		l.articleLinks[article.ID] = markdownx.Links(doc)
+		l.articleAnchors[article.ID] = set.NewStringSetWithValues(
+			lo.Map(headings, func(h markdownx.Heading, _ int) string { return h.ID }),
+		)
+
+		text := markdownx.Text(doc)
+		l.articleTexts[article.ID] = text
+
+		stats := textx.Count(text)
+		l.blogData.Articles[idx].WordCount = stats.Words()
+		l.blogData.Articles[idx].CharCount = stats.Chars
+		l.blogData.Articles[idx].ReadingTime = stats.ReadingMinutes(envs.ReadingSpeedCJK, envs.ReadingSpeedLatin)
 	}
 	return nil
 }
 
-// 从元数据中采集分类信息
+// imageResolver 解析文章中的图片尺寸及各版本地址，图片缺失时仅打印警告（渲染为普通的图片标签）
+func (l *BlogLoader) imageResolver(articleID string) markdownx.ImageResolver {
+	return func(src string) *markdownx.ImageInfo {
+		info, err := l.images.Resolve(src)
+		if err != nil {
+			logging.GetSystemLogger().Warnf("article %s: %s", articleID, err.Error())
+		}
+		return info
+	}
+}
+
+// admonitionIcons 提示块图标，与页面使用同一套图标（模板 icon.html 中的 common.icon.<类型>）
+func admonitionIcons() (map[markdownx.AdmonitionKind]string, error) {
+	tmpl, err := template.ParseFS(assets.Templates(), "webfe/icon.html")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse icon template")
+	}
+	icons := make(map[markdownx.AdmonitionKind]string, len(markdownx.AdmonitionKinds))
+	for _, kind := range markdownx.AdmonitionKinds {
+		var sb strings.Builder
+		if err = tmpl.ExecuteTemplate(&sb, "common.icon."+string(kind), nil); err != nil {
+			return nil, errors.Wrapf(err, "failed to render icon of admonition %s", kind)
+		}
+		icons[kind] = strings.TrimSpace(sb.String())
+	}
+	return icons, nil
+}
+
+// buildTOC 根据标题列表生成嵌套的目录，标题之后等级更低（数值更大）的标题都是其子目录
+func buildTOC(headings []markdownx.Heading) []model.TOCItem {
+	var items []model.TOCItem
+	for i := 0; i < len(headings); {
+		end := i + 1
+		for end < len(headings) && headings[end].Level > headings[i].Level {
+			end++
+		}
+		items = append(items, model.TOCItem{
+			Level:    headings[i].Level,
+			Text:     headings[i].Text,
+			Anchor:   headings[i].ID,
+			Children: buildTOC(headings[i+1 : end]),
+		})
+		i = end
+	}
+	return items
+}
+
+// 从元数据中采集分类信息（按名称排序，集合的遍历顺序不固定）
 func (l *BlogLoader) collectCategories() error {
 	categories := set.NewStringSet()
 	for _, article := range l.blogData.Articles {
+		if err := checkTermName("category", article.Category, article.ID); err != nil {
+			return err
+		}
 		categories.Append(article.Category)
 	}
 	l.blogData.Categories = categories.ToSlice()
+	slices.Sort(l.blogData.Categories)
 	return nil
 }
 
-// 从元数据中采集标签信息
+// 从元数据中采集标签信息（按名称排序）
 func (l *BlogLoader) collectTags() error {
 	tags := set.NewStringSet()
 	for _, article := range l.blogData.Articles {
+		for _, tag := range article.Tags {
+			if err := checkTermName("tag", tag, article.ID); err != nil {
+				return err
+			}
+		}
 		tags.Append(article.Tags...)
 	}
 	l.blogData.Tags = tags.ToSlice()
+	slices.Sort(l.blogData.Tags)
+	return nil
+}
+
+// checkTermName 检查分类 / 标签名称：分类 / 标签页的路径为 /categories/<名称>，/tags/<名称>，
+// 路由前会将 %2F 解码为 /，因此名称中不能包含 /（否则页面无法访问）
+func checkTermName(kind, name, articleID string) error {
+	if name == "" || strings.Contains(name, "/") {
+		return errors.Errorf("article %s has invalid %s %q, which must be non-empty and not contain /", articleID, kind, name)
+	}
+	return nil
+}
+
+// 加载元素周期表（非必须，加载失败只打印日志，页面会提示功能开发中）
+func (l *BlogLoader) loadPeriodicTable() error {
+	logger := logging.GetSystemLogger()
+
+	content, err := fs.ReadFile(assets.Data(), "periodic_table.json")
+	if err != nil {
+		logger.Errorf("failed to load periodic table: %s", err.Error())
+		return nil
+	}
+
+	var periodicTable model.ElementPeriodicTable
+	if err = json.Unmarshal(content, &periodicTable); err != nil {
+		logger.Errorf("failed to unmarshal periodic table: %s", err.Error())
+		return nil
+	}
+	l.blogData.PeriodicTable = &periodicTable
+	l.blogData.PeriodicTableETag = hashOf(string(content))
+	return nil
+}
+
+// 计算 HTTP 缓存校验所需的 ETag 与最后修改时间
+func (l *BlogLoader) computeCacheValidators() error {
+	etags := make(map[string]string, len(l.blogData.Articles))
+	updatedAts := make(map[string]time.Time, len(l.blogData.Articles))
+	for idx, article := range l.blogData.Articles {
+		// 详情页会展示相关文章 / 上一篇 / 下一篇 / 反向链接 / 系列导航，其标题变化时同样需要更新
+		links := append(slices.Clone(article.Related), lo.FromPtr(article.Prev), lo.FromPtr(article.Next))
+		links = append(links, article.Backlinks...)
+		series, _ := json.Marshal(article.Series)
+		etag := hashOf(
+			article.ID, article.Category, strings.Join(article.Tags, ","),
+			article.Title, article.Desc, article.UpdatedAt, article.Content,
+			fmt.Sprint(links), string(series),
+		)
+		l.blogData.Articles[idx].ETag = etag
+		l.blogData.Articles[idx].OGImageETag = hashOf(
+			article.Title, article.Category, strings.Join(article.Tags, ","), article.UpdatedAt,
+		)
+		etags[article.ID] = etag
+
+		updatedAt, err := time.ParseInLocation(time.DateOnly, article.UpdatedAt, time.Local)
+		if err == nil {
+			updatedAts[article.ID] = updatedAt
+		}
+	}
+	for idx, article := range l.blogData.Articles {
+		_, l.blogData.Articles[idx].LastModified = aggregateValidators(linkedArticleIDs(article), etags, updatedAts)
+	}
+	l.blogData.ETag, l.blogData.LastModified = aggregateValidators(
+		lo.Map(l.blogData.Articles, func(a model.Article, _ int) string { return a.ID }), etags, updatedAts,
+	)
+
+	for idx, series := range l.blogData.Series {
+		etag, lastModified := aggregateValidators(series.ArticleIDs, etags, updatedAts)
+		l.blogData.Series[idx].ETag = hashOf(series.ID, series.Title, series.Desc, etag)
+		l.blogData.Series[idx].LastModified = lastModified
+	}
 	return nil
 }
+
+// 文章详情页中展示的所有文章（含文章本身）的 ID
+func linkedArticleIDs(article model.Article) []string {
+	links := append(slices.Clone(article.Related), article.Backlinks...)
+	if article.Series != nil {
+		links = append(links, article.Series.Articles...)
+	}
+	ids := []string{article.ID}
+	for _, link := range append(links, lo.FromPtr(article.Prev), lo.FromPtr(article.Next)) {
+		if link.ID != "" {
+			ids = append(ids, link.ID)
+		}
+	}
+	return ids
+}
+
+// aggregateValidators 计算多篇文章聚合页面（如 RSS）的 ETag 与最后修改时间
+func aggregateValidators(
+	articleIDs []string, etags map[string]string, updatedAts map[string]time.Time,
+) (etag string, lastModified time.Time) {
+	parts := make([]string, 0, len(articleIDs))
+	for _, articleID := range articleIDs {
+		parts = append(parts, etags[articleID])
+		if updatedAts[articleID].After(lastModified) {
+			lastModified = updatedAts[articleID]
+		}
+	}
+	return hashOf(parts...), lastModified
+}
+
+// 计算内容哈希，混入版本信息，确保模板等随版本变更后缓存失效
+func hashOf(parts ...string) string {
+	h := sha256.New()
+	h.Write([]byte(version.Version + version.GitCommit))
+	for _, part := range parts {
+		// 以 \x00 分隔，避免不同的切分方式得到相同的哈希
+		h.Write([]byte{0})
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
@@ -0,0 +1,285 @@
+package loader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TencentBlueKing/gopkg/collection/set"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/utils/markdownx"
+)
+
+func TestBuildTOC(t *testing.T) {
+	headings := []markdownx.Heading{
+		{Level: 2, Text: "思考", ID: "思考"},
+		{Level: 2, Text: "pprof 工具", ID: "pprof-工具"},
+		{Level: 3, Text: "pprof top", ID: "pprof-top"},
+		{Level: 4, Text: "flat", ID: "flat"},
+		{Level: 3, Text: "pprof list", ID: "pprof-list"},
+		// 跳级的标题同样作为子目录
+		{Level: 2, Text: "代码改造", ID: "代码改造"},
+		{Level: 4, Text: "1. 添加 pprof", ID: "1-添加-pprof"},
+		// 比首个标题等级更高的标题，与之同级
+		{Level: 1, Text: "总结", ID: "总结"},
+	}
+
+	expected := []model.TOCItem{
+		{Level: 2, Text: "思考", Anchor: "思考"},
+		{Level: 2, Text: "pprof 工具", Anchor: "pprof-工具", Children: []model.TOCItem{
+			{Level: 3, Text: "pprof top", Anchor: "pprof-top", Children: []model.TOCItem{
+				{Level: 4, Text: "flat", Anchor: "flat"},
+			}},
+			{Level: 3, Text: "pprof list", Anchor: "pprof-list"},
+		}},
+		{Level: 2, Text: "代码改造", Anchor: "代码改造", Children: []model.TOCItem{
+			{Level: 4, Text: "1. 添加 pprof", Anchor: "1-添加-pprof"},
+		}},
+		{Level: 1, Text: "总结", Anchor: "总结"},
+	}
+	assert.Equal(t, expected, buildTOC(headings))
+	assert.Nil(t, buildTOC(nil))
+}
+
+func TestComputeRelatedArticles(t *testing.T) {
+	l := New()
+	l.blogData.Articles = model.Articles{
+		{ID: "k8s-scaling", Title: "K8s 扩缩容", Category: "云原生", Tags: []string{"K8s", "HPA"}, UpdatedAt: "2024-03-01"},
+		{ID: "k8s-vcluster", Title: "vcluster", Category: "云原生", Tags: []string{"K8s"}, UpdatedAt: "2024-05-01"},
+		{ID: "helm-crd", Title: "Helm 与 CRD", Category: "云原生", Tags: []string{"Helm"}, UpdatedAt: "2024-01-01"},
+		{ID: "go-leak", Title: "Go 内存泄漏", Category: "Golang", Tags: []string{"Go"}, UpdatedAt: "2024-05-01"},
+		{ID: "wontons", Title: "鲜肉馄饨", Category: "美食", Tags: []string{"食谱"}, UpdatedAt: "2023-12-01"},
+		{ID: "docker-init", Title: "容器中的多进程", Category: "云原生", Tags: []string{"Docker"}, UpdatedAt: "2024-01-01"},
+	}
+	// 停用词（我们 / 可以 / 使用）及大部分文章中都出现的词（开发）不参与相似度计算
+	l.articleTexts = map[string]string{
+		"k8s-scaling":  "我们可以使用 HPA 扩缩容 Kubernetes 集群中的工作负载，开发时需要注意指标的选择",
+		"k8s-vcluster": "我们可以使用 vcluster 共享 Kubernetes 集群，方便开发",
+		"helm-crd":     "我们可以使用 Helm 管理 Kubernetes 集群中的 CRD 资源",
+		"go-leak":      "我们可以使用 pprof 排查开发中遇到的内存泄漏",
+		"wontons":      "我们可以使用鲜肉开发出馄饨的新做法",
+		"docker-init":  "我们可以使用 tini 作为容器的一号进程，开发更方便",
+	}
+	assert.Nil(t, l.computeRelatedArticles())
+
+	articles := l.blogData.Articles
+	// 标签 > 正文相似度，仅分类相同（docker-init）不算相关
+	assert.Equal(t, []model.ArticleLink{
+		{ID: "k8s-vcluster", Title: "vcluster"},
+		{ID: "helm-crd", Title: "Helm 与 CRD"},
+	}, articles[0].Related)
+	// 与其他文章只有停用词 / 常见词相同，不推荐任何文章
+	assert.Empty(t, articles[3].Related)
+	assert.Empty(t, articles[4].Related)
+
+	// 按更新日期排序，日期相同时按 ID 排序
+	assert.Equal(t, &model.ArticleLink{ID: "helm-crd", Title: "Helm 与 CRD"}, articles[0].Prev)
+	assert.Equal(t, &model.ArticleLink{ID: "go-leak", Title: "Go 内存泄漏"}, articles[0].Next)
+	assert.Equal(t, &model.ArticleLink{ID: "go-leak", Title: "Go 内存泄漏"}, articles[1].Prev)
+	assert.Nil(t, articles[1].Next)
+	assert.Nil(t, articles[4].Prev)
+}
+
+func TestApplySeries(t *testing.T) {
+	newArticles := func() model.Articles {
+		return model.Articles{{ID: "a", Title: "A"}, {ID: "b", Title: "B"}, {ID: "c", Title: "C"}}
+	}
+
+	articles := newArticles()
+	err := applySeries(articles, model.SeriesList{{ID: "s", Title: "S", ArticleIDs: []string{"c", "a"}}})
+	assert.Nil(t, err)
+
+	links := []model.ArticleLink{{ID: "c", Title: "C"}, {ID: "a", Title: "A"}}
+	assert.Equal(t, &model.SeriesNav{
+		ID: "s", Title: "S", Index: 1, Total: 2, Articles: links, Next: &links[1],
+	}, articles[2].Series)
+	assert.Equal(t, &model.SeriesNav{
+		ID: "s", Title: "S", Index: 2, Total: 2, Articles: links, Prev: &links[0],
+	}, articles[0].Series)
+	assert.Nil(t, articles[1].Series)
+
+	// 配置错误
+	for _, seriesList := range []model.SeriesList{
+		{{ID: "", ArticleIDs: []string{"a"}}},
+		{{ID: "s", ArticleIDs: []string{"a"}}, {ID: "s", ArticleIDs: []string{"b"}}},
+		{{ID: "s", ArticleIDs: []string{"a", "x"}}},
+		{{ID: "s", ArticleIDs: []string{"a"}}, {ID: "t", ArticleIDs: []string{"b", "a"}}},
+		{{ID: "s", ArticleIDs: []string{"a", "a"}}},
+	} {
+		assert.NotNil(t, applySeries(newArticles(), seriesList))
+	}
+}
+
+func TestBuildRedirects(t *testing.T) {
+	articles := model.Articles{{ID: "a", Aliases: []string{"old-a"}}, {ID: "b"}}
+
+	redirects, err := buildRedirects(articles, model.Redirects{{From: "/posts/*", To: "/articles/*"}})
+	assert.Nil(t, err)
+	assert.Equal(t, model.Redirects{
+		{From: "/articles/old-a", To: "/articles/a"},
+		{From: "/posts/*", To: "/articles/*"},
+	}, redirects)
+
+	// 别名与文章 ID / 其他别名冲突
+	_, err = buildRedirects(model.Articles{{ID: "a", Aliases: []string{"b"}}, {ID: "b"}}, nil)
+	assert.ErrorContains(t, err, "alias b of article a conflicts with article b")
+	_, err = buildRedirects(model.Articles{{ID: "a", Aliases: []string{"x"}}, {ID: "b", Aliases: []string{"x"}}}, nil)
+	assert.ErrorContains(t, err, "alias x of article b conflicts with alias of article a")
+
+	// 不合法的规则
+	for _, rules := range []model.Redirects{
+		{{From: "/articles/old-a", To: "/"}},
+		{{From: "/articles/b", To: "/"}},
+		{{From: "posts/*", To: "/articles/*"}},
+		{{From: "/posts/*/*", To: "/articles/*"}},
+		{{From: "/feed", To: "/articles/*"}},
+		{{From: "/feed", To: ""}},
+	} {
+		_, err = buildRedirects(articles, rules)
+		assert.NotNil(t, err, rules)
+	}
+}
+
+func TestCheckArticleLinks(t *testing.T) {
+	articles := model.Articles{{ID: "a", Title: "A"}, {ID: "b", Title: "B"}, {ID: "c", Title: "C"}}
+	redirects := model.Redirects{{From: "/articles/old-b", To: "/articles/b"}, {From: "/posts/*", To: "/articles/*"}}
+	anchors := map[string]*set.StringSet{
+		"a": set.NewStringSetWithValues([]string{"简介"}),
+		"b": set.NewStringSetWithValues([]string{"安装"}),
+		"c": set.NewStringSet(),
+	}
+	links := map[string][]string{
+		"a": {
+			"/articles/b#安装", "/articles/b/", "https://" + envs.Domain + "/articles/c", "#简介",
+			"https://example.com/articles/x", "/static/a.png", "/series/s",
+		},
+		"b": {"/articles/old-b", "/posts/a#%E7%AE%80%E4%BB%8B"},
+		"c": {"/articles/x", "/articles/a#不存在", "#缺失"},
+	}
+
+	backlinks, problems := checkArticleLinks(articles, redirects, links, anchors)
+	assert.Equal(t, map[string][]model.ArticleLink{
+		"a": {{ID: "b", Title: "B"}, {ID: "c", Title: "C"}},
+		"b": {{ID: "a", Title: "A"}},
+		"c": {{ID: "a", Title: "A"}},
+	}, backlinks)
+	assert.Equal(t, []string{
+		"articles/c.md: link /articles/x points to unknown article x",
+		"articles/c.md: link /articles/a#不存在 points to missing heading #不存在",
+		"articles/c.md: link #缺失 points to missing heading #缺失",
+	}, problems)
+}
+
+func TestBuildArchives(t *testing.T) {
+	articles := model.Articles{
+		{ID: "k8s-scaling", Title: "K8s 扩缩容", UpdatedAt: "2024-03-01"},
+		{ID: "wontons", Title: "鲜肉馄饨", UpdatedAt: "2023-12-01"},
+		{ID: "k8s-vcluster", Title: "vcluster", UpdatedAt: "2024-05-01"},
+		{ID: "go-leak", Title: "Go 内存泄漏", UpdatedAt: "2024-05-01"},
+		{ID: "helm-crd", Title: "Helm 与 CRD", UpdatedAt: "2024-03-20"},
+	}
+
+	archives, err := buildArchives(articles)
+	assert.Nil(t, err)
+	expected := model.Archives{
+		{Year: 2024, Count: 4, Months: []model.ArchiveMonth{
+			// 同一天的文章按 ID 排序
+			{Year: 2024, Month: 5, Count: 2, Articles: []model.ArchiveArticle{
+				{ID: "go-leak", Title: "Go 内存泄漏", Date: "2024-05-01"},
+				{ID: "k8s-vcluster", Title: "vcluster", Date: "2024-05-01"},
+			}},
+			{Year: 2024, Month: 3, Count: 2, Articles: []model.ArchiveArticle{
+				{ID: "helm-crd", Title: "Helm 与 CRD", Date: "2024-03-20"},
+				{ID: "k8s-scaling", Title: "K8s 扩缩容", Date: "2024-03-01"},
+			}},
+		}},
+		{Year: 2023, Count: 1, Months: []model.ArchiveMonth{
+			{Year: 2023, Month: 12, Count: 1, Articles: []model.ArchiveArticle{
+				{ID: "wontons", Title: "鲜肉馄饨", Date: "2023-12-01"},
+			}},
+		}},
+	}
+	assert.Equal(t, expected, archives)
+
+	_, err = buildArchives(model.Articles{{ID: "draft", UpdatedAt: "2024/03/01"}})
+	assert.EqualError(t, err, `article draft has invalid date "2024/03/01", expected format is YYYY-MM-DD`)
+}
+
+func TestCollectCategoriesAndTags(t *testing.T) {
+	l := New()
+	l.blogData.Articles = model.Articles{
+		{ID: "k8s-scaling", Category: "云原生", Tags: []string{"K8s", "HPA"}},
+		{ID: "go-leak", Category: "Golang", Tags: []string{"Go", "K8s"}},
+		{ID: "wontons", Category: "美食", Tags: []string{"食谱"}},
+	}
+
+	// 多次采集的结果一致（按名称排序）
+	for range 5 {
+		assert.Nil(t, l.collectCategories())
+		assert.Nil(t, l.collectTags())
+		assert.Equal(t, []string{"Golang", "云原生", "美食"}, l.blogData.Categories)
+		assert.Equal(t, []string{"Go", "HPA", "K8s", "食谱"}, l.blogData.Tags)
+	}
+
+	// 名称中包含 / 的分类 / 标签页面无法访问
+	l.blogData.Articles = model.Articles{{ID: "ci", Category: "DevOps", Tags: []string{"CI/CD"}}}
+	assert.Nil(t, l.collectCategories())
+	assert.EqualError(t, l.collectTags(), `article ci has invalid tag "CI/CD", which must be non-empty and not contain /`)
+	l.blogData.Articles = model.Articles{{ID: "ci", Category: "Dev/Ops", Tags: []string{"CI"}}}
+	assert.EqualError(t, l.collectCategories(), `article ci has invalid category "Dev/Ops", which must be non-empty and not contain /`)
+	l.blogData.Articles = model.Articles{{ID: "ci", Category: "DevOps", Tags: []string{""}}}
+	assert.NotNil(t, l.collectTags())
+}
+
+func TestComputeCacheValidators(t *testing.T) {
+	compute := func(article model.Article) model.Article {
+		l := &BlogLoader{blogData: model.BlogData{Articles: model.Articles{article}}}
+		assert.Nil(t, l.computeCacheValidators())
+		return l.blogData.Articles[0]
+	}
+	base := model.Article{ID: "a", Title: "A", Category: "C", Tags: []string{"T"}, UpdatedAt: "2024-01-01", Content: "x"}
+	expected := compute(base)
+	assert.NotEqual(t, expected.ETag, expected.OGImageETag)
+
+	// 正文，相关文章等变化时，预览图不变
+	changed := base
+	changed.Content = "y"
+	changed.Related = []model.ArticleLink{{ID: "b", Title: "B"}}
+	actual := compute(changed)
+	assert.NotEqual(t, expected.ETag, actual.ETag)
+	assert.Equal(t, expected.OGImageETag, actual.OGImageETag)
+
+	// 标题，分类，标签，日期变化时，预览图需要重新生成
+	for _, change := range []func(a *model.Article){
+		func(a *model.Article) { a.Title = "B" },
+		func(a *model.Article) { a.Category = "D" },
+		func(a *model.Article) { a.Tags = []string{"T", "U"} },
+		func(a *model.Article) { a.UpdatedAt = "2024-01-02" },
+	} {
+		changed = base
+		change(&changed)
+		assert.NotEqual(t, expected.OGImageETag, compute(changed).OGImageETag)
+	}
+}
+
+func TestArticleLastModified(t *testing.T) {
+	l := &BlogLoader{blogData: model.BlogData{Articles: model.Articles{
+		{ID: "a", UpdatedAt: "2024-01-01", Related: []model.ArticleLink{{ID: "b"}}},
+		{ID: "b", UpdatedAt: "2024-03-01", Prev: &model.ArticleLink{ID: "c"}},
+		{ID: "c", UpdatedAt: "2024-02-01", Series: &model.SeriesNav{Articles: []model.ArticleLink{{ID: "c"}, {ID: "d"}}}},
+		{ID: "d", UpdatedAt: "2024-05-01"},
+		{ID: "e", UpdatedAt: "2024-04-01", Backlinks: []model.ArticleLink{{ID: "a"}}},
+	}}}
+	assert.Nil(t, l.computeCacheValidators())
+
+	// 详情页的最后修改时间取文章及其展示的其他文章中最新的更新时间
+	date := func(s string) time.Time {
+		d, _ := time.ParseInLocation(time.DateOnly, s, time.Local)
+		return d
+	}
+	for idx, expected := range []string{"2024-03-01", "2024-03-01", "2024-05-01", "2024-05-01", "2024-04-01"} {
+		assert.Equal(t, date(expected), l.blogData.Articles[idx].LastModified, l.blogData.Articles[idx].ID)
+	}
+}
@@ -0,0 +1,82 @@
+package loader
+
+import (
+	"encoding/json"
+	"io/fs"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/narasux/goblog/pkg/assets"
+	"github.com/narasux/goblog/pkg/model"
+)
+
+// 加载重定向规则：文章别名 + redirects.json（非必须，文件不存在时跳过）
+func (l *BlogLoader) loadRedirects() error {
+	var rules model.Redirects
+	content, err := fs.ReadFile(assets.Data(), "redirects.json")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		if err = json.Unmarshal(content, &rules); err != nil {
+			return errors.Wrap(err, "failed to unmarshal redirects.json")
+		}
+	}
+
+	l.blogData.Redirects, err = buildRedirects(l.blogData.Articles, rules)
+	return err
+}
+
+// buildRedirects 将文章别名转换为重定向规则，并与 redirects.json 中的规则合并，
+// 别名 / 规则与文章 ID 或其他别名 / 规则冲突时返回错误
+func buildRedirects(articles model.Articles, rules model.Redirects) (model.Redirects, error) {
+	// 原路径 -> 来源（用于冲突时的错误信息）
+	sources := make(map[string]string, len(articles))
+	for _, article := range articles {
+		sources[articlePath(article.ID)] = "article " + article.ID
+	}
+
+	var redirects model.Redirects
+	for _, article := range articles {
+		for _, alias := range article.Aliases {
+			if alias == "" || strings.ContainsAny(alias, "/"+model.RedirectWildcard) {
+				return nil, errors.Errorf("article %s has invalid alias %q", article.ID, alias)
+			}
+			from := articlePath(alias)
+			if source, ok := sources[from]; ok {
+				return nil, errors.Errorf("alias %s of article %s conflicts with %s", alias, article.ID, source)
+			}
+			sources[from] = "alias of article " + article.ID
+			redirects = append(redirects, model.Redirect{From: from, To: articlePath(article.ID)})
+		}
+	}
+
+	for _, rule := range rules {
+		if !strings.HasPrefix(rule.From, "/") || rule.To == "" {
+			return nil, errors.Errorf("invalid redirect %s -> %s, from must start with / and to is required", rule.From, rule.To)
+		}
+		if strings.Count(rule.From, model.RedirectWildcard) > 1 || strings.Count(rule.To, model.RedirectWildcard) > 1 {
+			return nil, errors.Errorf("redirect %s -> %s has more than one wildcard", rule.From, rule.To)
+		}
+		if !rule.IsWildcard() && strings.Contains(rule.To, model.RedirectWildcard) {
+			return nil, errors.Errorf("redirect %s -> %s uses wildcard in to but not in from", rule.From, rule.To)
+		}
+		if !rule.IsWildcard() {
+			if source, ok := sources[rule.From]; ok {
+				return nil, errors.Errorf("redirect %s -> %s conflicts with %s", rule.From, rule.To, source)
+			}
+			sources[rule.From] = "redirect to " + rule.To
+		}
+		redirects = append(redirects, rule)
+	}
+	return redirects, nil
+}
+
+// 文章详情页路径前缀
+const articlePathPrefix = "/articles/"
+
+// 文章详情页路径
+func articlePath(id string) string {
+	return articlePathPrefix + id
+}
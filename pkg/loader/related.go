@@ -0,0 +1,113 @@
+package loader
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/utils/textx"
+)
+
+// 相关文章评分权重：每个相同的标签 / 相同的分类 / 正文 TF-IDF 余弦相似度（0 ~ 1）
+const (
+	relatedTagWeight      = 1.0
+	relatedCategoryWeight = 0.5
+	relatedTextWeight     = 3.0
+)
+
+const (
+	// 正文相似度低于该值时视为不相关（平滑 IDF 下任意两篇文章的相似度几乎都不为 0）
+	relatedMinTextSimilarity = 0.1
+	// 在超过该比例的文章中都出现的词不参与正文相似度计算
+	relatedMaxDocRatio = 0.5
+)
+
+// 计算相关文章（相同标签，相同分类，正文相似度）及上一篇 / 下一篇（按更新日期）
+func (l *BlogLoader) computeRelatedArticles() error {
+	articles := l.blogData.Articles
+
+	docs := make([][]string, len(articles))
+	for idx, article := range articles {
+		docs[idx] = textx.RemoveStopwords(textx.Tokenize(l.articleTexts[article.ID]))
+	}
+	vectors := textx.TFIDF(textx.RemoveCommonTokens(docs, relatedMaxDocRatio))
+
+	for idx := range articles {
+		articles[idx].Related = relatedArticles(articles, vectors, idx, envs.RelatedArticlesCount)
+	}
+
+	// 按更新日期排序（同一天的按 ID 排序），上一篇为更早的文章
+	sorted := slices.Clone(articles)
+	slices.SortStableFunc(sorted, func(a, b model.Article) int {
+		return lo.Ternary(a.UpdatedAt != b.UpdatedAt, strings.Compare(a.UpdatedAt, b.UpdatedAt), strings.Compare(a.ID, b.ID))
+	})
+	positions := make(map[string]int, len(sorted))
+	for pos, article := range sorted {
+		positions[article.ID] = pos
+	}
+	for idx, article := range articles {
+		pos := positions[article.ID]
+		if pos > 0 {
+			articles[idx].Prev = linkOf(sorted[pos-1])
+		}
+		if pos < len(sorted)-1 {
+			articles[idx].Next = linkOf(sorted[pos+1])
+		}
+	}
+	return nil
+}
+
+// relatedArticles 获取与指定文章最相关的 topN 篇文章，
+// 只有存在相同标签或正文足够相似的文章才计入（仅分类相同不算相关，大部分文章都属于同一个分类）
+func relatedArticles(articles model.Articles, vectors []textx.Vector, idx, topN int) []model.ArticleLink {
+	type candidate struct {
+		article model.Article
+		score   float64
+	}
+
+	target := articles[idx]
+	var candidates []candidate
+	for i, article := range articles {
+		if i == idx {
+			continue
+		}
+		sharedTags := len(lo.Intersect(target.Tags, article.Tags))
+		// 浮点数累加顺序（map 遍历）不同可能导致细微差异，保留 6 位小数以确保结果稳定
+		similarity := math.Round(textx.Cosine(vectors[idx], vectors[i])*1e6) / 1e6
+		if similarity < relatedMinTextSimilarity {
+			similarity = 0
+		}
+		if sharedTags == 0 && similarity == 0 {
+			continue
+		}
+		score := relatedTagWeight*float64(sharedTags) +
+			lo.Ternary(target.Category == article.Category, relatedCategoryWeight, 0) +
+			relatedTextWeight*similarity
+		candidates = append(candidates, candidate{article: article, score: math.Round(score*1e6) / 1e6})
+	}
+
+	// 得分相同时，优先展示更新的文章
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.score != b.score:
+			return lo.Ternary(a.score > b.score, -1, 1)
+		case a.article.UpdatedAt != b.article.UpdatedAt:
+			return strings.Compare(b.article.UpdatedAt, a.article.UpdatedAt)
+		}
+		return strings.Compare(a.article.ID, b.article.ID)
+	})
+
+	var related []model.ArticleLink
+	for _, c := range lo.Slice(candidates, 0, topN) {
+		related = append(related, *linkOf(c.article))
+	}
+	return related
+}
+
+func linkOf(article model.Article) *model.ArticleLink {
+	return &model.ArticleLink{ID: article.ID, Title: article.Title}
+}
@@ -0,0 +1,80 @@
+package loader
+
+import (
+	"encoding/json"
+	"io/fs"
+
+	"github.com/pkg/errors"
+
+	"github.com/narasux/goblog/pkg/assets"
+	"github.com/narasux/goblog/pkg/model"
+)
+
+// 加载文章系列（非必须，文件不存在时跳过），并为系列中的文章设置导航信息
+func (l *BlogLoader) loadSeries() error {
+	content, err := fs.ReadFile(assets.Data(), "series.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(content, &l.blogData.Series); err != nil {
+		return errors.Wrap(err, "failed to unmarshal series.json")
+	}
+	return applySeries(l.blogData.Articles, l.blogData.Series)
+}
+
+// applySeries 校验系列配置（ID 不可重复，文章必须存在且只能属于一个系列），并设置文章的系列导航信息
+func applySeries(articles model.Articles, seriesList model.SeriesList) error {
+	indexes := make(map[string]int, len(articles))
+	for idx, article := range articles {
+		indexes[article.ID] = idx
+	}
+
+	seriesIDs := map[string]bool{}
+	// 文章 ID -> 所属系列 ID（一篇文章只能属于一个系列）
+	memberOf := map[string]string{}
+	for _, series := range seriesList {
+		if series.ID == "" {
+			return errors.Errorf("series %q has empty id", series.Title)
+		}
+		if seriesIDs[series.ID] {
+			return errors.Errorf("duplicate series id %s", series.ID)
+		}
+		seriesIDs[series.ID] = true
+
+		links := make([]model.ArticleLink, 0, len(series.ArticleIDs))
+		for _, articleID := range series.ArticleIDs {
+			idx, ok := indexes[articleID]
+			if !ok {
+				return errors.Errorf("series %s contains unknown article %s", series.ID, articleID)
+			}
+			if other, ok := memberOf[articleID]; ok && other == series.ID {
+				return errors.Errorf("series %s contains article %s more than once", series.ID, articleID)
+			} else if ok {
+				return errors.Errorf("article %s belongs to both series %s and %s", articleID, other, series.ID)
+			}
+			memberOf[articleID] = series.ID
+			links = append(links, *linkOf(articles[idx]))
+		}
+
+		for pos, articleID := range series.ArticleIDs {
+			nav := &model.SeriesNav{
+				ID:       series.ID,
+				Title:    series.Title,
+				Index:    pos + 1,
+				Total:    len(links),
+				Articles: links,
+			}
+			if pos > 0 {
+				nav.Prev = &links[pos-1]
+			}
+			if pos < len(links)-1 {
+				nav.Next = &links[pos+1]
+			}
+			articles[indexes[articleID]].Series = nav
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"path"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl 为路由组设置默认的 Cache-Control 头（value 为空则不设置），handler 可自行覆盖
+func CacheControl(value string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if value != "" {
+			c.Header("Cache-Control", value)
+		}
+		c.Next()
+	}
+}
+
+// 文件名中的指纹，如 app.3f2a9c1b.js
+var fingerprintRegex = regexp.MustCompile(`\.[0-9a-fA-F]{8,}\.[^.]+$`)
+
+// StaticCacheControl 静态文件缓存控制，带指纹的文件（文件名包含哈希，或 ?v= 版本参数与文件当前的指纹一致）
+// 内容不会发生变化，可以长期缓存，其余文件使用默认配置；fingerprint 根据路由中的 filepath 计算文件指纹，为 nil 时不检查 ?v=
+func StaticCacheControl(value, fingerprintedValue string, fingerprint func(name string) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fingerprinted := fingerprintRegex.MatchString(path.Base(c.Request.URL.Path))
+		if v := c.Query("v"); v != "" && fingerprint != nil {
+			fingerprinted = fingerprinted || v == fingerprint(c.Param("filepath"))
+		}
+		if fingerprinted && fingerprintedValue != "" {
+			c.Header("Cache-Control", fingerprintedValue)
+		} else if value != "" {
+			c.Header("Cache-Control", value)
+		}
+		c.Next()
+	}
+}
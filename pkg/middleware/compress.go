@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/narasux/goblog/pkg/utils/compressx"
+	"github.com/narasux/goblog/pkg/utils/ginx"
+)
+
+// 可压缩的响应类型（静态文件由 handler.ServeStatic 返回预压缩的文件）
+var compressibleContentTypes = []string{
+	"text/html",
+	"application/json",
+	"application/xml",
+	"text/xml",
+	"application/atom+xml",
+	"application/rss+xml",
+}
+
+// 各编码的压缩 Writer 池，避免每个请求都重新分配压缩窗口
+var encoderPools = map[string]*sync.Pool{}
+
+func init() {
+	for _, encoding := range compressx.Encodings {
+		encoderPools[encoding] = &sync.Pool{
+			New: func() any { return compressx.NewWriter(encoding, io.Discard, false) },
+		}
+	}
+}
+
+type resettableWriter interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+type compressWriter struct {
+	gin.ResponseWriter
+	request  *http.Request
+	encoding string
+	// 是否已经决定过是否压缩（在第一次写入 body 时决定）
+	decided bool
+	encoder resettableWriter
+}
+
+// Write ...
+func (w *compressWriter) Write(b []byte) (int, error) {
+	w.decide()
+	if w.encoder == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.encoder.Write(b)
+}
+
+// WriteString ...
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush ...
+func (w *compressWriter) Flush() {
+	if f, ok := w.encoder.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide 根据响应状态码及响应头，决定是否压缩
+func (w *compressWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+
+	header := w.Header()
+	status := w.Status()
+	// 范围请求的响应（206，416 等带 Content-Range 的响应）中的偏移量针对原始内容，压缩后会错位
+	if w.request.Method == http.MethodHead ||
+		status == http.StatusNoContent || status == http.StatusNotModified ||
+		status == http.StatusPartialContent || header.Get("Content-Range") != "" ||
+		header.Get("Content-Encoding") != "" ||
+		!isCompressible(header.Get("Content-Type")) {
+		return
+	}
+
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+	// 压缩后的 ETag 与原始内容不同，使用弱校验
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", "W/"+etag)
+	}
+
+	w.encoder = encoderPools[w.encoding].Get().(resettableWriter)
+	w.encoder.Reset(w.ResponseWriter)
+}
+
+// close 刷新并回收压缩 Writer
+func (w *compressWriter) close() {
+	if w.encoder == nil {
+		return
+	}
+	_ = w.encoder.Close()
+	w.encoder.Reset(io.Discard)
+	encoderPools[w.encoding].Put(w.encoder)
+	w.encoder = nil
+}
+
+func isCompressible(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+	for _, ct := range compressibleContentTypes {
+		if mediaType == ct {
+			return true
+		}
+	}
+	return false
+}
+
+// Compress 根据 Accept-Encoding 协商，对 HTML / JSON / XML 响应进行 br / gzip 压缩
+//
+// 注：需要在 Logger 之前注册，以确保日志中记录的是压缩前的响应内容
+func Compress() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 无论是否压缩，响应内容都与 Accept-Encoding 相关
+		ginx.AddVary(c, "Accept-Encoding")
+
+		encoding := compressx.Negotiate(c.GetHeader("Accept-Encoding"), compressx.Encodings)
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, request: c.Request, encoding: encoding}
+		c.Writer = writer
+		defer writer.close()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,102 @@
+package middleware_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/middleware"
+)
+
+func TestCompress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := strings.Repeat("<p>hello goblog</p>", 100)
+
+	router := gin.New()
+	router.Use(middleware.Compress())
+	router.GET("/html", func(c *gin.Context) {
+		c.Header("ETag", `"abc"`)
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(body))
+	})
+	router.GET("/js", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/javascript", []byte(body))
+	})
+	router.GET("/range", func(c *gin.Context) {
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		http.ServeContent(c.Writer, c.Request, "", time.Time{}, strings.NewReader(body))
+	})
+	router.GET("/304", func(c *gin.Context) {
+		c.Header("Content-Type", "text/html")
+		c.Status(http.StatusNotModified)
+	})
+
+	request := func(path, acceptEncoding string, rangeHeader ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		if len(rangeHeader) != 0 {
+			req.Header.Set("Range", rangeHeader[0])
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// brotli 优先
+	w := request("/html", "gzip, br")
+	assert.Equal(t, "br", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Equal(t, `W/"abc"`, w.Header().Get("ETag"))
+	content, err := io.ReadAll(brotli.NewReader(w.Body))
+	assert.Nil(t, err)
+	assert.Equal(t, body, string(content))
+
+	// gzip
+	w = request("/html", "gzip")
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	reader, err := gzip.NewReader(w.Body)
+	assert.Nil(t, err)
+	content, err = io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, body, string(content))
+
+	// 客户端不支持压缩
+	w = request("/html", "")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, body, w.Body.String())
+
+	// 非 HTML / JSON / XML 类型不压缩
+	w = request("/js", "gzip, br")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, body, w.Body.String())
+
+	// 范围请求的响应不压缩，否则 Content-Range 与响应体不一致
+	w = request("/range", "gzip, br", "bytes=0-9")
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "bytes 0-9/"+strconv.Itoa(len(body)), w.Header().Get("Content-Range"))
+	assert.Equal(t, body[:10], w.Body.String())
+
+	w = request("/range", "gzip, br", "bytes=100000-")
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+
+	// 没有 Range 头时正常压缩
+	w = request("/range", "gzip")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+
+	// 304 没有响应体，不压缩
+	w = request("/304", "gzip, br")
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+}
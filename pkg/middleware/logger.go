@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"bytes"
+	"net/http"
 	"time"
 
 	"github.com/TencentBlueKing/gopkg/stringx"
@@ -60,6 +61,7 @@ func Logger() gin.HandlerFunc {
 		fields := logrus.Fields{
 			"method":    c.Request.Method,
 			"path":      c.Request.URL.Path,
+			"referer":   c.Request.Referer(),
 			"params":    params,
 			"reqBody":   reqBody,
 			"respBody":  respBody,
@@ -72,9 +74,13 @@ func Logger() gin.HandlerFunc {
 		}
 
 		logger := logging.GetAccessLogger()
-		if hasErr {
+		switch {
+		case hasErr:
 			logger.WithFields(fields).Error("-")
-		} else {
+		case c.Writer.Status() == http.StatusNotFound:
+			// 404 通常意味着站内外存在失效链接（可通过 goblog report-404 汇总），需要关注
+			logger.WithFields(fields).Warn("-")
+		default:
 			logger.WithFields(fields).Info("-")
 		}
 	}
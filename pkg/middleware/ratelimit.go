@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/narasux/goblog/pkg/logging"
+	"github.com/narasux/goblog/pkg/ratelimit"
+	"github.com/narasux/goblog/pkg/utils/ginx"
+)
+
+// RateLimit 按客户端 IP 限流，scope 用于区分不同路由组的令牌桶
+func RateLimit(store ratelimit.Store, scope string, limit ratelimit.Limit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !limit.Enabled() {
+			c.Next()
+			return
+		}
+
+		key := scope + ":" + ginx.GetClientIP(c)
+		result, err := store.Take(c.Request.Context(), key, limit)
+		if err != nil {
+			// 限流存储异常时放行，不影响正常访问
+			logging.GetSystemLogger().Errorf("failed to take token from rate limit store: %s", err.Error())
+			c.Next()
+			return
+		}
+
+		if !result.Allowed {
+			retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+			ginx.SetErrResp(c, http.StatusTooManyRequests, "too many requests, please retry later")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/middleware"
+	"github.com/narasux/goblog/pkg/ratelimit"
+	"github.com/narasux/goblog/pkg/utils/ginx"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.RateLimit(ratelimit.NewMemoryStore(), "test", ratelimit.PerMinute(1, 2)))
+	router.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for range 2 {
+		assert.Equal(t, http.StatusOK, request("203.0.113.7:1234").Code)
+	}
+
+	w := request("203.0.113.7:1234")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	var resp ginx.Response
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.NotEmpty(t, resp.Message)
+
+	// 其他客户端不受影响
+	assert.Equal(t, http.StatusOK, request("203.0.113.8:1234").Code)
+}
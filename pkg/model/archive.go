@@ -0,0 +1,35 @@
+package model
+
+// ArchiveArticle 归档中的文章
+type ArchiveArticle struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// Date 更新日期（即 articles.json 中的 updateAt，文章没有单独的发布日期）
+	Date string `json:"date"`
+}
+
+// ArchiveMonth 按月归档（文章按日期降序）
+type ArchiveMonth struct {
+	Year     int              `json:"year"`
+	Month    int              `json:"month"`
+	Count    int              `json:"count"`
+	Articles []ArchiveArticle `json:"articles"`
+}
+
+// ArchiveYear 按年归档（月份降序）
+type ArchiveYear struct {
+	Year   int            `json:"year"`
+	Count  int            `json:"count"`
+	Months []ArchiveMonth `json:"months"`
+}
+
+// Archives 文章归档（年份降序）
+type Archives []ArchiveYear
+
+// LastDate 最新文章的日期
+func (m *ArchiveMonth) LastDate() string {
+	if len(m.Articles) == 0 {
+		return ""
+	}
+	return m.Articles[0].Date
+}
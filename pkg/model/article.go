@@ -1,5 +1,11 @@
 package model
 
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
 // Article 文章
 type Article struct {
 	ID        string   `json:"id"`
@@ -9,48 +15,123 @@ type Article struct {
 	Desc      string   `json:"desc"`
 	UpdatedAt string   `json:"updateAt"`
 	Content   string   `json:"content"`
+	// Markdown 文章的 markdown 原文（展开短代码前），仅在 API 中按需返回
+	Markdown string `json:"-"`
+	// Cover 封面图（站内路径，如 /static/image/blog/xxx.png，或完整 URL），用于分享卡片
+	Cover string `json:"cover,omitempty"`
+	// Aliases 文章的曾用 ID（如重命名文件后），访问 /articles/<alias> 时会重定向到当前文章
+	Aliases []string `json:"aliases,omitempty"`
+	// TOC 文章目录，加载时根据文章中的标题生成
+	TOC []TOCItem `json:"toc"`
+	// WordCount 字数（中日韩字符数 + 拉丁文单词数，不含代码块），加载时统计
+	WordCount int `json:"wordCount"`
+	// CharCount 字符数（不含空白字符及代码块）
+	CharCount int `json:"charCount"`
+	// ReadingTime 预计阅读时间（分钟）
+	ReadingTime int `json:"readingTime"`
+	// Related 相关文章，加载时根据标签，分类及正文相似度计算
+	Related []ArticleLink `json:"related"`
+	// Prev 上一篇（更早更新的）文章
+	Prev *ArticleLink `json:"prev"`
+	// Next 下一篇（更晚更新的）文章
+	Next *ArticleLink `json:"next"`
+	// Backlinks 反向链接（正文中链接到本文的其他文章），加载时根据站内链接生成
+	Backlinks []ArticleLink `json:"backlinks"`
+	// Series 文章所属系列（不属于任何系列时为 nil）
+	Series *SeriesNav `json:"series"`
+	// ETag 文章内容哈希，加载时计算，用于 HTTP 缓存校验
+	ETag string `json:"-"`
+	// LastModified 文章及详情页中展示的其他文章（相关文章，上一篇 / 下一篇，反向链接，系列）的最新更新时间
+	LastModified time.Time `json:"-"`
+	// OGImageETag 预览图内容（标题，分类，标签，日期）的哈希，用于预览图的缓存，与正文，相关文章等无关
+	OGImageETag string `json:"-"`
+}
+
+// ArticleLink 站内文章链接（用于相关文章，上一篇 / 下一篇等）
+type ArticleLink struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// TOCItem 文章目录项
+type TOCItem struct {
+	// 标题等级（1 ~ 6）
+	Level int `json:"level"`
+	// 标题文本
+	Text string `json:"text"`
+	// 锚点（标题 ID，不含 #）
+	Anchor string `json:"anchor"`
+	// 子目录（等级更低的标题）
+	Children []TOCItem `json:"children,omitempty"`
 }
 
 // Articles 文章列表
 type Articles []Article
 
+// TermCount 分类 / 标签及其文章数
+type TermCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
 // BlogData 博客数据
 type BlogData struct {
 	Categories []string `json:"categories"`
 	Tags       []string `json:"tags"`
 	Articles   Articles `json:"articles"`
+	// Series 文章系列
+	Series SeriesList `json:"series"`
+	// Archives 按更新日期的归档
+	Archives Archives `json:"archives"`
+	// Redirects 重定向规则（文章别名 + redirects.json）
+	Redirects Redirects `json:"-"`
+	// ETag 所有文章的内容哈希，用于 RSS 等聚合页面的缓存校验
+	ETag string `json:"-"`
+	// LastModified 最新文章的更新时间
+	LastModified time.Time `json:"-"`
+	// PeriodicTable 元素周期表（加载失败时为 nil）
+	PeriodicTable *ElementPeriodicTable `json:"-"`
+	// PeriodicTableETag 元素周期表 JSON 的内容哈希
+	PeriodicTableETag string `json:"-"`
 }
 
-// GetByID 根据 ID 获取文章
+// GetByID 根据 ID 获取文章（遍历查找，加载完成后应使用 storage.Store 的索引）
 func (as Articles) GetByID(id string) *Article {
-	for _, article := range as {
-		if article.ID == id {
-			return &article
+	for idx := range as {
+		if as[idx].ID == id {
+			return &as[idx]
 		}
 	}
 	return nil
 }
 
-// FilterByCategory 根据分类过滤文章
-func (as Articles) FilterByCategory(category string) Articles {
-	var articles Articles
+// CountByCategory 统计各分类的文章数（按文章数降序，相同时按名称升序）
+func (as Articles) CountByCategory() []TermCount {
+	counts := map[string]int{}
 	for _, article := range as {
-		if article.Category == category {
-			articles = append(articles, article)
-		}
+		counts[article.Category]++
 	}
-	return articles
+	return sortTermCounts(counts)
 }
 
-// FilterByTag 根据标签过滤文章
-func (as Articles) FilterByTag(tag string) Articles {
-	var articles Articles
+// CountByTag 统计各标签的文章数（按文章数降序，相同时按名称升序）
+func (as Articles) CountByTag() []TermCount {
+	counts := map[string]int{}
 	for _, article := range as {
-		for _, t := range article.Tags {
-			if t == tag {
-				articles = append(articles, article)
-			}
+		for _, tag := range slices.Compact(slices.Sorted(slices.Values(article.Tags))) {
+			counts[tag]++
 		}
 	}
-	return articles
+	return sortTermCounts(counts)
+}
+
+func sortTermCounts(counts map[string]int) []TermCount {
+	terms := make([]TermCount, 0, len(counts))
+	for name, count := range counts {
+		terms = append(terms, TermCount{Name: name, Count: count})
+	}
+	slices.SortFunc(terms, func(a, b TermCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+	return terms
 }
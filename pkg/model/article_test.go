@@ -0,0 +1,31 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/model"
+)
+
+func TestArticlesCountBy(t *testing.T) {
+	articles := model.Articles{
+		{ID: "a", Category: "Go", Tags: []string{"gin", "web"}},
+		{ID: "b", Category: "Python", Tags: []string{"django", "web", "web"}},
+		{ID: "c", Category: "Go", Tags: []string{"gin"}},
+	}
+
+	assert.Equal(t, []model.TermCount{
+		{Name: "Go", Count: 2},
+		{Name: "Python", Count: 1},
+	}, articles.CountByCategory())
+
+	// 数量相同时按名称排序，文章中重复的标签只统计一次
+	assert.Equal(t, []model.TermCount{
+		{Name: "gin", Count: 2},
+		{Name: "web", Count: 2},
+		{Name: "django", Count: 1},
+	}, articles.CountByTag())
+
+	assert.Empty(t, model.Articles{}.CountByTag())
+}
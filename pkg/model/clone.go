@@ -0,0 +1,147 @@
+package model
+
+import "slices"
+
+// Clone 深拷贝文章（包括标签，目录，相关文章等切片及指针字段），修改副本不影响原文章
+func (a *Article) Clone() *Article {
+	if a == nil {
+		return nil
+	}
+	clone := *a
+	clone.Tags = slices.Clone(a.Tags)
+	clone.Aliases = slices.Clone(a.Aliases)
+	clone.TOC = cloneTOC(a.TOC)
+	clone.Related = slices.Clone(a.Related)
+	clone.Backlinks = slices.Clone(a.Backlinks)
+	clone.Prev = cloneLink(a.Prev)
+	clone.Next = cloneLink(a.Next)
+	clone.Series = a.Series.Clone()
+	return &clone
+}
+
+// Clone 深拷贝文章列表
+func (as Articles) Clone() Articles {
+	if as == nil {
+		return nil
+	}
+	clone := make(Articles, len(as))
+	for idx := range as {
+		clone[idx] = *as[idx].Clone()
+	}
+	return clone
+}
+
+// Clone 深拷贝系列导航
+func (n *SeriesNav) Clone() *SeriesNav {
+	if n == nil {
+		return nil
+	}
+	clone := *n
+	clone.Articles = slices.Clone(n.Articles)
+	clone.Prev = cloneLink(n.Prev)
+	clone.Next = cloneLink(n.Next)
+	return &clone
+}
+
+// Clone 深拷贝系列
+func (s *Series) Clone() *Series {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	clone.ArticleIDs = slices.Clone(s.ArticleIDs)
+	return &clone
+}
+
+// Clone 深拷贝系列列表
+func (sl SeriesList) Clone() SeriesList {
+	if sl == nil {
+		return nil
+	}
+	clone := make(SeriesList, len(sl))
+	for idx := range sl {
+		clone[idx] = *sl[idx].Clone()
+	}
+	return clone
+}
+
+// Clone 深拷贝月份归档
+func (m *ArchiveMonth) Clone() *ArchiveMonth {
+	if m == nil {
+		return nil
+	}
+	clone := *m
+	clone.Articles = slices.Clone(m.Articles)
+	return &clone
+}
+
+// Clone 深拷贝年份归档
+func (y *ArchiveYear) Clone() *ArchiveYear {
+	if y == nil {
+		return nil
+	}
+	clone := *y
+	if y.Months != nil {
+		clone.Months = make([]ArchiveMonth, len(y.Months))
+		for idx := range y.Months {
+			clone.Months[idx] = *y.Months[idx].Clone()
+		}
+	}
+	return &clone
+}
+
+// Clone 深拷贝归档
+func (as Archives) Clone() Archives {
+	if as == nil {
+		return nil
+	}
+	clone := make(Archives, len(as))
+	for idx := range as {
+		clone[idx] = *as[idx].Clone()
+	}
+	return clone
+}
+
+func cloneTOC(items []TOCItem) []TOCItem {
+	if items == nil {
+		return nil
+	}
+	clone := make([]TOCItem, len(items))
+	for idx, item := range items {
+		item.Children = cloneTOC(item.Children)
+		clone[idx] = item
+	}
+	return clone
+}
+
+func cloneLink(link *ArticleLink) *ArticleLink {
+	if link == nil {
+		return nil
+	}
+	clone := *link
+	return &clone
+}
+
+// Clone 深拷贝元素周期表
+func (t *ElementPeriodicTable) Clone() *ElementPeriodicTable {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	if t.Groups != nil {
+		clone.Groups = make([]ElementGroup, len(t.Groups))
+		for i, group := range t.Groups {
+			if group.Elements != nil {
+				group.Elements = make([]Element, len(t.Groups[i].Elements))
+				for j, element := range t.Groups[i].Elements {
+					element.Examples = slices.Clone(element.Examples)
+					element.WatchOuts = slices.Clone(element.WatchOuts)
+					element.Articles = slices.Clone(element.Articles)
+					group.Elements[j] = element
+				}
+			}
+			clone.Groups[i] = group
+		}
+	}
+	return &clone
+}
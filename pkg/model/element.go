@@ -45,3 +45,15 @@ type ElementPeriodicTable struct {
 	Source string         `json:"source"`
 	Groups []ElementGroup `json:"groups"`
 }
+
+// GetElement 根据符号获取元素及其所属的族，不存在则返回 nil
+func (t *ElementPeriodicTable) GetElement(symbol string) (*Element, *ElementGroup) {
+	for i := range t.Groups {
+		for j := range t.Groups[i].Elements {
+			if t.Groups[i].Elements[j].Symbol == symbol {
+				return &t.Groups[i].Elements[j], &t.Groups[i]
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,52 @@
+package model
+
+import "strings"
+
+// RedirectWildcard 重定向规则中的通配符，匹配任意字符（包括 /），匹配到的内容会替换目标路径中的通配符
+const RedirectWildcard = "*"
+
+// Redirect 重定向规则（响应 301）
+type Redirect struct {
+	// From 原路径，如 /articles/old-id，/posts/*
+	From string `json:"from"`
+	// To 目标路径，如 /articles/new-id，/articles/*
+	To string `json:"to"`
+}
+
+// IsWildcard 是否为通配规则
+func (r Redirect) IsWildcard() bool {
+	return strings.Contains(r.From, RedirectWildcard)
+}
+
+// Match 匹配路径，返回重定向的目标路径
+func (r Redirect) Match(path string) (string, bool) {
+	prefix, suffix, wildcard := strings.Cut(r.From, RedirectWildcard)
+	if !wildcard {
+		return r.To, path == r.From
+	}
+	if len(path) < len(prefix)+len(suffix) || !strings.HasPrefix(path, prefix) || !strings.HasSuffix(path, suffix) {
+		return "", false
+	}
+	return strings.Replace(r.To, RedirectWildcard, path[len(prefix):len(path)-len(suffix)], 1), true
+}
+
+// Redirects 重定向规则列表
+type Redirects []Redirect
+
+// Match 匹配路径，精确规则优先，通配规则按顺序匹配
+func (rs Redirects) Match(path string) (string, bool) {
+	for _, r := range rs {
+		if !r.IsWildcard() && r.From == path {
+			return r.To, true
+		}
+	}
+	for _, r := range rs {
+		if !r.IsWildcard() {
+			continue
+		}
+		if to, ok := r.Match(path); ok {
+			return to, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,37 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/model"
+)
+
+func TestRedirectsMatch(t *testing.T) {
+	redirects := model.Redirects{
+		{From: "/posts/*", To: "/articles/*"},
+		{From: "/old/*.html", To: "/articles/*"},
+		{From: "/docs/*", To: "https://docs.example.com/"},
+		{From: "/posts/about", To: "/"},
+	}
+
+	for _, c := range []struct {
+		path     string
+		expected string
+		ok       bool
+	}{
+		// 精确规则优先于通配规则
+		{"/posts/about", "/", true},
+		{"/posts/k8s-scaling", "/articles/k8s-scaling", true},
+		{"/posts/", "/articles/", true},
+		{"/old/go-leak.html", "/articles/go-leak", true},
+		{"/old/go-leak", "", false},
+		{"/docs/a/b", "https://docs.example.com/", true},
+		{"/articles/k8s-scaling", "", false},
+	} {
+		to, ok := redirects.Match(c.path)
+		assert.Equal(t, c.ok, ok, c.path)
+		assert.Equal(t, c.expected, to, c.path)
+	}
+}
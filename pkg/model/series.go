@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Series 文章系列（多篇文章组成的合集，按顺序阅读）
+type Series struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Desc  string `json:"desc"`
+	// ArticleIDs 系列中的文章 ID（按阅读顺序）
+	ArticleIDs []string `json:"articles"`
+	// ETag 系列中所有文章的内容哈希，用于系列页面 / RSS 的缓存校验
+	ETag string `json:"-"`
+	// LastModified 系列中最新文章的更新时间
+	LastModified time.Time `json:"-"`
+}
+
+// SeriesList 文章系列列表
+type SeriesList []Series
+
+// GetByID 根据 ID 获取系列
+func (sl SeriesList) GetByID(id string) *Series {
+	for idx := range sl {
+		if sl[idx].ID == id {
+			return &sl[idx]
+		}
+	}
+	return nil
+}
+
+// SeriesNav 文章所属系列的导航信息
+type SeriesNav struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// Index 当前文章是系列中的第几篇（从 1 开始）
+	Index int `json:"index"`
+	// Total 系列的文章总数
+	Total int `json:"total"`
+	// Articles 系列中的所有文章（按阅读顺序）
+	Articles []ArticleLink `json:"articles"`
+	// Prev 系列中的上一篇
+	Prev *ArticleLink `json:"prev"`
+	// Next 系列中的下一篇
+	Next *ArticleLink `json:"next"`
+}
@@ -0,0 +1,225 @@
+// Package ogimage 生成文章的社交分享预览图（Open Graph Image），纯 Go 实现，不依赖外部工具
+package ogimage
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"io/fs"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/narasux/goblog/pkg/assets"
+	"github.com/narasux/goblog/pkg/utils/textx"
+)
+
+const (
+	// Width 预览图宽度（Open Graph 推荐 1200 x 630）
+	Width = 1200
+	// Height 预览图高度
+	Height = 630
+
+	// 字体文件（文泉驿微米黑，支持中日韩字符，相对静态文件目录）
+	fontFile = "webfonts/wqy-microhei.ttf"
+	// 页边距
+	padding = 80
+	// 标题最多展示的行数，超出的部分以省略号结尾
+	maxTitleLines = 3
+)
+
+var (
+	// 背景色（与站点的 bg-yellow-50 一致）
+	bgColor = color.RGBA{R: 0xfe, G: 0xfc, B: 0xe8, A: 0xff}
+	// 主题色（sky-500）
+	themeColor = color.RGBA{R: 0x0e, G: 0xa5, B: 0xe9, A: 0xff}
+	// 标题颜色（gray-700）
+	titleColor = color.RGBA{R: 0x37, G: 0x41, B: 0x51, A: 0xff}
+	// 分类 / 标签颜色（gray-500）
+	metaColor = color.RGBA{R: 0x6b, G: 0x72, B: 0x80, A: 0xff}
+)
+
+// Card 预览图内容
+type Card struct {
+	Title    string
+	Category string
+	Tags     []string
+	SiteName string
+}
+
+var (
+	loadFontOnce sync.Once
+	loadedFont   *sfnt.Font
+	loadFontErr  error
+)
+
+// 加载字体（仅加载一次）
+func loadFont() (*sfnt.Font, error) {
+	loadFontOnce.Do(func() {
+		content, err := fs.ReadFile(assets.Static(), fontFile)
+		if err != nil {
+			loadFontErr = errors.Wrapf(err, "failed to read font %s", fontFile)
+			return
+		}
+		if loadedFont, err = opentype.Parse(content); err != nil {
+			loadFontErr = errors.Wrapf(err, "failed to parse font %s", fontFile)
+		}
+	})
+	return loadedFont, loadFontErr
+}
+
+// Render 渲染预览图，以 PNG 格式写入 w
+func Render(w io.Writer, card Card) error {
+	f, err := loadFont()
+	if err != nil {
+		return err
+	}
+	titleFace, err := opentype.NewFace(f, &opentype.FaceOptions{Size: 64, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return err
+	}
+	defer titleFace.Close()
+	metaFace, err := opentype.NewFace(f, &opentype.FaceOptions{Size: 32, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return err
+	}
+	defer metaFace.Close()
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(bgColor), image.Point{}, draw.Src)
+	// 顶部的主题色色条
+	draw.Draw(img, image.Rect(0, 0, Width, 16), image.NewUniform(themeColor), image.Point{}, draw.Src)
+
+	// 分类 & 标签
+	y := padding + 40
+	if meta := metaLine(card.Category, card.Tags); meta != "" {
+		drawText(img, metaFace, metaColor, padding, y, truncate(metaFace, meta, Width-2*padding))
+		y += 56
+	}
+
+	// 标题（自动换行）
+	y += 48
+	for _, line := range wrap(titleFace, card.Title, Width-2*padding, maxTitleLines) {
+		drawText(img, titleFace, titleColor, padding, y, line)
+		y += 88
+	}
+
+	// 站点名称
+	drawText(img, metaFace, themeColor, padding, Height-padding, card.SiteName)
+
+	return png.Encode(w, img)
+}
+
+// 分类 & 标签行，如：技术分享 · #Python #Celery
+func metaLine(category string, tags []string) string {
+	parts := make([]string, 0, len(tags)+2)
+	if category != "" {
+		parts = append(parts, category)
+	}
+	if len(tags) != 0 {
+		if category != "" {
+			parts = append(parts, "·")
+		}
+		for _, tag := range tags {
+			parts = append(parts, "#"+tag)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func drawText(img draw.Image, face font.Face, c color.Color, x, y int, text string) {
+	d := font.Drawer{Dst: img, Src: image.NewUniform(c), Face: face, Dot: fixed.P(x, y)}
+	d.DrawString(text)
+}
+
+// wrap 按宽度将文本折行：中日韩字符之间可以断行，拉丁文单词不拆分（除非单词本身超过一行），
+// 超过 maxLines 行时，最后一行以省略号结尾
+func wrap(face font.Face, text string, maxWidth, maxLines int) []string {
+	limit := fixed.I(maxWidth)
+	var lines []string
+	var line string
+	for _, seg := range segments(text) {
+		if line == "" {
+			seg = strings.TrimLeft(seg, " ")
+		}
+		if font.MeasureString(face, line+seg) <= limit {
+			line += seg
+			continue
+		}
+		if line != "" {
+			lines = append(lines, strings.TrimRight(line, " "))
+			seg = strings.TrimLeft(seg, " ")
+		}
+		// 单个片段超过一行时，按字符拆分
+		line = ""
+		for _, r := range seg {
+			if line != "" && font.MeasureString(face, line+string(r)) > limit {
+				lines = append(lines, line)
+				line = ""
+			}
+			line += string(r)
+		}
+	}
+	if strings.TrimSpace(line) != "" {
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] = truncate(face, lines[maxLines-1]+"…", maxWidth)
+	}
+	return lines
+}
+
+// truncate 截断文本使其不超过指定宽度，截断时以省略号结尾
+func truncate(face font.Face, text string, maxWidth int) string {
+	limit := fixed.I(maxWidth)
+	if font.MeasureString(face, text) <= limit {
+		return text
+	}
+	runes := []rune(strings.TrimSuffix(text, "…"))
+	for len(runes) > 0 && font.MeasureString(face, string(runes)+"…") > limit {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimRight(string(runes), " ") + "…"
+}
+
+// segments 将文本切分为不可断行的片段：每个中日韩字符 / 标点为一个片段，
+// 拉丁文单词（含其前面的空白字符）为一个片段
+func segments(text string) []string {
+	var segs []string
+	var seg strings.Builder
+	flush := func() {
+		if seg.Len() != 0 {
+			segs = append(segs, seg.String())
+			seg.Reset()
+		}
+	}
+	for _, r := range text {
+		switch {
+		case textx.IsCJK(r) || isCJKPunct(r):
+			flush()
+			segs = append(segs, string(r))
+		case unicode.IsSpace(r):
+			flush()
+			seg.WriteRune(' ')
+		default:
+			seg.WriteRune(r)
+		}
+	}
+	flush()
+	return segs
+}
+
+// 中文标点（全角字符）
+func isCJKPunct(r rune) bool {
+	return (r >= 0x3000 && r <= 0x303f) || (r >= 0xff00 && r <= 0xffef)
+}
@@ -0,0 +1,72 @@
+package ogimage
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestRender(t *testing.T) {
+	var buf bytes.Buffer
+	err := Render(&buf, Card{
+		Title:    "Kubernetes 中的扩缩容（HPA / VPA / KEDA）",
+		Category: "技术分享",
+		Tags:     []string{"K8s", "HPA"},
+		SiteName: "Narasux Blogs",
+	})
+	assert.Nil(t, err)
+
+	img, err := png.Decode(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, Width, img.Bounds().Dx())
+	assert.Equal(t, Height, img.Bounds().Dy())
+}
+
+func TestSegments(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"Go", " ", "内", "存", "泄", "漏", "：", "pprof", " in", " action"},
+		segments("Go 内存泄漏：pprof in action"),
+	)
+}
+
+func TestWrap(t *testing.T) {
+	f, err := loadFont()
+	assert.Nil(t, err)
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: 64, DPI: 72})
+	assert.Nil(t, err)
+	defer face.Close()
+
+	maxWidth := Width - 2*padding
+	fits := func(lines []string) {
+		for _, line := range lines {
+			assert.LessOrEqual(t, font.MeasureString(face, line), fixed.I(maxWidth), line)
+			assert.Equal(t, strings.TrimSpace(line), line)
+		}
+	}
+
+	// 短标题不换行
+	assert.Equal(t, []string{"Pipe in Python"}, wrap(face, "Pipe in Python", maxWidth, maxTitleLines))
+
+	// 拉丁文单词不拆分
+	lines := wrap(face, strings.Repeat("kubernetes ", 8), maxWidth, maxTitleLines)
+	assert.Greater(t, len(lines), 1)
+	fits(lines)
+	for _, line := range lines {
+		for _, word := range strings.Fields(line) {
+			assert.Equal(t, "kubernetes", word)
+		}
+	}
+
+	// 超出行数时以省略号结尾
+	lines = wrap(face, strings.Repeat("计算机系统设计中的元素周期表", 10), maxWidth, maxTitleLines)
+	assert.Len(t, lines, maxTitleLines)
+	assert.True(t, strings.HasSuffix(lines[maxTitleLines-1], "…"))
+	fits(lines)
+}
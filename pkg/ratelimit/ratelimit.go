@@ -0,0 +1,65 @@
+// Package ratelimit 基于令牌桶算法的限流器，支持本地内存与 Redis 两种存储
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit 令牌桶限制
+type Limit struct {
+	// Rate 每秒补充的令牌数
+	Rate float64
+	// Burst 桶容量，即允许的最大突发请求数
+	Burst int
+}
+
+// PerMinute 以每分钟请求数构造限制，burst <= 0 时与每分钟请求数一致
+func PerMinute(count, burst int) Limit {
+	if burst <= 0 {
+		burst = count
+	}
+	return Limit{Rate: float64(count) / 60, Burst: burst}
+}
+
+// Enabled 是否启用限流（速率与容量均大于 0）
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// 令牌从空到满所需时间
+func (l Limit) fillDuration() time.Duration {
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// Result 取令牌结果
+type Result struct {
+	// Allowed 是否放行
+	Allowed bool
+	// RetryAfter 被拒绝时，需要等待多久才会有可用令牌
+	RetryAfter time.Duration
+}
+
+// Store 令牌桶存储
+type Store interface {
+	// Take 从 key 对应的令牌桶中取一个令牌
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// take 根据上次的令牌数及时间，计算补充令牌后取一个令牌的结果及剩余令牌数
+func take(tokens float64, elapsed time.Duration, limit Limit) (Result, float64) {
+	tokens = math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate)
+	if tokens >= 1 {
+		return Result{Allowed: true}, tokens - 1
+	}
+	wait := time.Duration(math.Ceil((1 - tokens) / limit.Rate * float64(time.Second)))
+	return Result{Allowed: false, RetryAfter: wait}, tokens
+}
+
+const (
+	// StoreTypeMemory 本地内存存储
+	StoreTypeMemory = "memory"
+	// StoreTypeRedis Redis 存储
+	StoreTypeRedis = "redis"
+)
@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// 可手动拨动的时钟
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newStores(t *testing.T, clock *fakeClock) map[string]Store {
+	memStore := NewMemoryStore()
+	memStore.now = clock.Now
+
+	// 使用 miniredis 作为本地替身
+	mr := miniredis.RunT(t)
+	rdsStore := NewRedisStore(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "test:")
+	rdsStore.now = clock.Now
+
+	return map[string]Store{StoreTypeMemory: memStore, StoreTypeRedis: rdsStore}
+}
+
+func TestPerMinute(t *testing.T) {
+	assert.Equal(t, Limit{Rate: 1, Burst: 10}, PerMinute(60, 10))
+	assert.Equal(t, Limit{Rate: 0.5, Burst: 30}, PerMinute(30, 0))
+	assert.False(t, PerMinute(0, 10).Enabled())
+}
+
+func TestStoreTake(t *testing.T) {
+	ctx := context.Background()
+	// 每秒 1 个令牌，最多突发 3 个
+	limit := Limit{Rate: 1, Burst: 3}
+
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	for name, store := range newStores(t, clock) {
+		t.Run(name, func(t *testing.T) {
+			// 桶满时允许突发
+			for range 3 {
+				ret, err := store.Take(ctx, "1.1.1.1", limit)
+				assert.Nil(t, err)
+				assert.True(t, ret.Allowed)
+			}
+
+			// 令牌耗尽
+			ret, err := store.Take(ctx, "1.1.1.1", limit)
+			assert.Nil(t, err)
+			assert.False(t, ret.Allowed)
+			assert.Equal(t, time.Second, ret.RetryAfter)
+
+			// 不同的 key 互不影响
+			ret, err = store.Take(ctx, "2.2.2.2", limit)
+			assert.Nil(t, err)
+			assert.True(t, ret.Allowed)
+
+			// 半秒后仍需等待半秒
+			clock.Advance(500 * time.Millisecond)
+			ret, err = store.Take(ctx, "1.1.1.1", limit)
+			assert.Nil(t, err)
+			assert.False(t, ret.Allowed)
+			assert.Equal(t, 500*time.Millisecond, ret.RetryAfter)
+
+			// 补充一个令牌
+			clock.Advance(500 * time.Millisecond)
+			ret, err = store.Take(ctx, "1.1.1.1", limit)
+			assert.Nil(t, err)
+			assert.True(t, ret.Allowed)
+
+			// 很久之后令牌最多补满到 burst
+			clock.Advance(time.Hour)
+			for range 3 {
+				ret, err = store.Take(ctx, "1.1.1.1", limit)
+				assert.Nil(t, err)
+				assert.True(t, ret.Allowed)
+			}
+			ret, err = store.Take(ctx, "1.1.1.1", limit)
+			assert.Nil(t, err)
+			assert.False(t, ret.Allowed)
+		})
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	store := NewMemoryStore()
+	store.now = clock.Now
+
+	limit := Limit{Rate: 1, Burst: 3}
+	_, _ = store.Take(context.Background(), "1.1.1.1", limit)
+	assert.Len(t, store.buckets, 1)
+
+	// 令牌已补满的桶会被清理
+	clock.Advance(2 * sweepInterval)
+	_, _ = store.Take(context.Background(), "2.2.2.2", limit)
+	assert.Len(t, store.buckets, 1)
+	assert.Contains(t, store.buckets, "2.2.2.2")
+}
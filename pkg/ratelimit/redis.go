@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 令牌桶 Lua 脚本，保证多实例并发时 读取-计算-写回 的原子性
+// KEYS[1]: 令牌桶 key
+// ARGV: rate（每秒令牌数）, burst, now（毫秒时间戳）, ttl（毫秒）
+// 返回：{是否放行, 需等待的毫秒数}
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local data = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(data[1])
+local ts = tonumber(data[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+return {allowed, wait}
+`)
+
+// RedisStore Redis 存储，多实例部署时共享限流状态
+type RedisStore struct {
+	client redis.Scripter
+	prefix string
+	now    func() time.Time
+}
+
+// NewRedisStore ...
+func NewRedisStore(client redis.Scripter, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix, now: time.Now}
+}
+
+// Take 从 key 对应的令牌桶中取一个令牌
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	// 令牌桶补满后即可过期，多保留 1s 避免临界情况
+	ttl := limit.fillDuration() + time.Second
+
+	ret, err := tokenBucketScript.Run(
+		ctx, s.client, []string{s.prefix + key},
+		limit.Rate, limit.Burst, s.now().UnixMilli(), ttl.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Allowed: ret[0] == 1, RetryAfter: time.Duration(ret[1]) * time.Millisecond}, nil
+}
@@ -0,0 +1,206 @@
+package router_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/storage"
+)
+
+// 通用响应体（data 按需解析）
+type apiResp[T any] struct {
+	Message   string `json:"message"`
+	Data      T      `json:"data"`
+	RequestID string `json:"requestID"`
+}
+
+type articleSummary struct {
+	ID        string   `json:"id"`
+	Category  string   `json:"category"`
+	Tags      []string `json:"tags"`
+	Title     string   `json:"title"`
+	UpdatedAt string   `json:"updateAt"`
+	WordCount int      `json:"wordCount"`
+}
+
+type articleList struct {
+	Count    int              `json:"count"`
+	PageNum  int              `json:"pageNum"`
+	PageSize int              `json:"pageSize"`
+	Results  []articleSummary `json:"results"`
+}
+
+type termCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// gin 路由参数（:id）对应 OpenAPI 的 {id}
+var routeParamRegex = regexp.MustCompile(`:(\w+)`)
+
+func getAPI[T any](t *testing.T, path string, expectedStatus int) apiResp[T] {
+	w := httptest.NewRecorder()
+	newEngine(t).ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, expectedStatus, w.Code, path)
+
+	var resp apiResp[T]
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp), path)
+	assert.NotEmpty(t, resp.RequestID, path)
+	return resp
+}
+
+func TestListArticlesV1(t *testing.T) {
+	store := storage.Current()
+	articles := store.Articles()
+
+	resp := getAPI[articleList](t, "/apis/v1/articles", http.StatusOK)
+	assert.Equal(t, len(articles), resp.Data.Count)
+	assert.Equal(t, 1, resp.Data.PageNum)
+	assert.Equal(t, 10, resp.Data.PageSize)
+	assert.Len(t, resp.Data.Results, 10)
+	// 默认最近更新的在前
+	for i := 1; i < len(resp.Data.Results); i++ {
+		assert.GreaterOrEqual(t, resp.Data.Results[i-1].UpdatedAt, resp.Data.Results[i].UpdatedAt)
+	}
+
+	// 最后一页 & 超出范围的页
+	lastPage := (len(articles)-1)/20 + 1
+	resp = getAPI[articleList](t, "/apis/v1/articles?page_size=20&page_num="+strconv.Itoa(lastPage), http.StatusOK)
+	assert.Len(t, resp.Data.Results, len(articles)-(lastPage-1)*20)
+	resp = getAPI[articleList](t, "/apis/v1/articles?page_num="+strconv.Itoa(lastPage+100), http.StatusOK)
+	assert.Equal(t, len(articles), resp.Data.Count)
+	assert.Empty(t, resp.Data.Results)
+	// 页码过大时 (pageNum-1)*pageSize 会溢出
+	resp = getAPI[articleList](t, "/apis/v1/articles?page_size=20&page_num=922337203685477580", http.StatusOK)
+	assert.Equal(t, len(articles), resp.Data.Count)
+	assert.NotNil(t, resp.Data.Results)
+	assert.Empty(t, resp.Data.Results)
+
+	// 按字数升序
+	resp = getAPI[articleList](t, "/apis/v1/articles?sort=wordCount&page_size=50", http.StatusOK)
+	for i := 1; i < len(resp.Data.Results); i++ {
+		assert.LessOrEqual(t, resp.Data.Results[i-1].WordCount, resp.Data.Results[i].WordCount)
+	}
+
+	// 过滤
+	article := articles[0]
+	resp = getAPI[articleList](t, "/apis/v1/articles?page_size=50&category="+article.Category, http.StatusOK)
+	assert.Equal(t, len(store.ArticlesByCategory(article.Category)), resp.Data.Count)
+	for _, result := range resp.Data.Results {
+		assert.Equal(t, article.Category, result.Category)
+	}
+	resp = getAPI[articleList](t, "/apis/v1/articles?page_size=50&tag="+article.Tags[0], http.StatusOK)
+	assert.NotZero(t, resp.Data.Count)
+	for _, result := range resp.Data.Results {
+		assert.Contains(t, result.Tags, article.Tags[0])
+	}
+
+	// 搜索（忽略大小写）
+	resp = getAPI[articleList](t, "/apis/v1/articles?q="+"DJANGO", http.StatusOK)
+	assert.NotZero(t, resp.Data.Count)
+	resp = getAPI[articleList](t, "/apis/v1/articles?q=not-exists-keyword", http.StatusOK)
+	assert.Zero(t, resp.Data.Count)
+	assert.NotNil(t, resp.Data.Results)
+
+	errResp := getAPI[any](t, "/apis/v1/articles?sort=-likes", http.StatusBadRequest)
+	assert.Equal(t, `invalid sort "-likes"`, errResp.Message)
+	assert.Nil(t, errResp.Data)
+}
+
+func TestRetrieveArticleV1(t *testing.T) {
+	article := storage.Current().Articles()[0]
+
+	resp := getAPI[map[string]any](t, "/apis/v1/articles/"+article.ID, http.StatusOK)
+	assert.Equal(t, article.ID, resp.Data["id"])
+	assert.Equal(t, article.Content, resp.Data["content"])
+	assert.NotContains(t, resp.Data, "markdown")
+	// 目录，阅读时间等均在详情中返回，内部字段（ETag 等）不返回
+	assert.Equal(t, float64(article.ReadingTime), resp.Data["readingTime"])
+	assert.Len(t, resp.Data["toc"], len(article.TOC))
+	assert.Subset(t, []string{
+		"id", "category", "tags", "title", "desc", "updateAt", "cover", "wordCount", "readingTime",
+		"content", "aliases", "charCount", "toc", "related", "backlinks", "prev", "next", "series",
+	}, lo.Keys(resp.Data))
+
+	resp = getAPI[map[string]any](t, "/apis/v1/articles/"+article.ID+"?markdown=true", http.StatusOK)
+	assert.Equal(t, article.Markdown, resp.Data["markdown"])
+	assert.NotEmpty(t, resp.Data["markdown"])
+
+	getAPI[any](t, "/apis/v1/articles/"+article.ID+"?markdown=yes", http.StatusBadRequest)
+	// 未版本化的文章详情 / 目录接口作为兼容别名保留
+	assert.Equal(t, article.ID, getAPI[model.Article](t, "/apis/articles/"+article.ID, http.StatusOK).Data.ID)
+	assert.Equal(t, article.TOC, getAPI[[]model.TOCItem](t, "/apis/articles/"+article.ID+"/toc", http.StatusOK).Data)
+	getAPI[any](t, "/apis/articles/not-exists/toc", http.StatusNotFound)
+	errResp := getAPI[any](t, "/apis/v1/articles/not-exists", http.StatusNotFound)
+	assert.Equal(t, "article not found", errResp.Message)
+
+	// 是否包含 markdown 的响应 ETag 不同
+	engine := newEngine(t)
+	etags := map[string]bool{}
+	for _, path := range []string{"/apis/v1/articles/" + article.ID, "/apis/v1/articles/" + article.ID + "?markdown=1"} {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		etag := w.Header().Get("ETag")
+		assert.NotEmpty(t, etag)
+		etags[etag] = true
+
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("If-None-Match", etag)
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code, path)
+	}
+	assert.Len(t, etags, 2)
+}
+
+func TestListCategoriesAndTagsV1(t *testing.T) {
+	store := storage.Current()
+
+	categories := getAPI[[]termCount](t, "/apis/v1/categories", http.StatusOK).Data
+	assert.Len(t, categories, len(store.Categories()))
+	total := 0
+	for _, category := range categories {
+		assert.Equal(t, len(store.ArticlesByCategory(category.Name)), category.Count, category.Name)
+		total += category.Count
+	}
+	assert.Equal(t, len(store.Articles()), total)
+
+	tags := getAPI[[]termCount](t, "/apis/v1/tags", http.StatusOK).Data
+	assert.Len(t, tags, len(store.Tags()))
+	for i := 1; i < len(tags); i++ {
+		assert.GreaterOrEqual(t, tags[i-1].Count, tags[i].Count)
+	}
+}
+
+func TestOpenAPIDoc(t *testing.T) {
+	w := httptest.NewRecorder()
+	engine := newEngine(t)
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/apis/v1/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var doc struct {
+		OpenAPI string                    `json:"openapi"`
+		Paths   map[string]map[string]any `json:"paths"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+
+	// 所有 v1 路由都需要有文档
+	for _, route := range engine.Routes() {
+		path, ok := strings.CutPrefix(route.Path, "/apis/v1")
+		if !ok {
+			continue
+		}
+		path = routeParamRegex.ReplaceAllString(path, "{$1}")
+		assert.Contains(t, doc.Paths[path], strings.ToLower(route.Method), route.Path)
+	}
+}
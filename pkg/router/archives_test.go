@@ -0,0 +1,149 @@
+package router_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/handler"
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/storage"
+)
+
+// 归档中文章的链接
+func archiveArticleHrefs(articles []model.ArchiveArticle) []string {
+	return lo.Map(articles, func(a model.ArchiveArticle, _ int) string { return "/articles/" + a.ID })
+}
+
+// 页面中的文章链接
+func articleHrefs(anchors []anchor) []string {
+	return lo.Filter(hrefs(anchors), func(href string, _ int) bool { return strings.HasPrefix(href, "/articles/") })
+}
+
+// 最早的年份之前的年份（一定没有文章）
+func emptyYear(store *storage.Store) int {
+	archives := store.Archives()
+	return archives[len(archives)-1].Year - 1
+}
+
+// 指定年份中没有文章的月份，每个月都有文章时返回 0
+func emptyMonth(store *storage.Store, year int) int {
+	for month := 1; month <= 12; month++ {
+		if store.ArchiveMonth(year, month) == nil {
+			return month
+		}
+	}
+	return 0
+}
+
+func TestArchivesPages(t *testing.T) {
+	store := storage.Current()
+	archives := store.Archives()
+	assert.NotEmpty(t, archives)
+
+	// 首页包含所有年份（含文章数）及所有文章
+	body := getBody(t, "/archives", http.StatusOK)
+	years := parseAnchors(t, body, "archive-years")
+	assert.Equal(t, "全部", years[0].text)
+	for i, year := range archives {
+		assert.Equal(t, handler.ArchivePath(year.Year, 0), years[i+1].href)
+		assert.Equal(t, fmt.Sprintf("%d（%d）", year.Year, year.Count), years[i+1].text)
+	}
+	assert.ElementsMatch(t, lo.Map(store.Articles(), func(a model.Article, _ int) string {
+		return "/articles/" + a.ID
+	}), articleHrefs(parseAnchors(t, body, "")))
+
+	// 年份页只包含该年的月份及文章
+	year := archives[0]
+	body = getBody(t, handler.ArchivePath(year.Year, 0), http.StatusOK)
+	head := parseHead(t, body)
+	assert.Equal(t, fmt.Sprintf("%d 年归档 - Narasux Blogs", year.Year), head.title)
+	assert.Equal(t, "https://www.narasux.cn"+handler.ArchivePath(year.Year, 0), head.canonical)
+	anchors := parseAnchors(t, body, "")
+	var expectedArticles []string
+	for _, month := range year.Months {
+		assert.Contains(t, anchors, anchor{
+			href: handler.ArchivePath(year.Year, month.Month), text: fmt.Sprintf("%d 月", month.Month), classes: []string{"hover:text-sky-500"},
+		})
+		expectedArticles = append(expectedArticles, archiveArticleHrefs(month.Articles)...)
+	}
+	assert.Equal(t, expectedArticles, articleHrefs(anchors))
+	for _, other := range archives[1:] {
+		for _, href := range hrefs(anchors) {
+			assert.False(t, strings.HasPrefix(href, handler.ArchivePath(other.Year, 0)+"/"), href)
+		}
+	}
+
+	// 月份可以不补 0，规范链接统一补 0
+	month := year.Months[len(year.Months)-1]
+	for _, path := range []string{
+		handler.ArchivePath(month.Year, month.Month),
+		fmt.Sprintf("/archives/%d/%d", month.Year, month.Month),
+	} {
+		body = getBody(t, path, http.StatusOK)
+		head = parseHead(t, body)
+		assert.Equal(t, fmt.Sprintf("%d 年 %d 月归档 - Narasux Blogs", month.Year, month.Month), head.title)
+		assert.Equal(t, "https://www.narasux.cn"+handler.ArchivePath(month.Year, month.Month), head.canonical)
+		assert.Equal(t, archiveArticleHrefs(month.Articles), articleHrefs(parseAnchors(t, body, "")))
+	}
+
+	notFound := []string{handler.ArchivePath(emptyYear(store), 0), "/archives/abc", fmt.Sprintf("/archives/%d/13", year.Year), fmt.Sprintf("/archives/%d/x", year.Year)}
+	if m := emptyMonth(store, year.Year); m != 0 {
+		notFound = append(notFound, handler.ArchivePath(year.Year, m))
+	}
+	for _, path := range notFound {
+		getBody(t, path, http.StatusNotFound)
+	}
+}
+
+func TestArchivesV1(t *testing.T) {
+	store := storage.Current()
+	years := getAPI[model.Archives](t, "/apis/v1/archives", http.StatusOK).Data
+	assert.Equal(t, store.Archives(), years)
+	total := 0
+	for _, year := range years {
+		total += year.Count
+		monthTotal := 0
+		for _, month := range year.Months {
+			monthTotal += month.Count
+			assert.Len(t, month.Articles, month.Count)
+		}
+		assert.Equal(t, year.Count, monthTotal)
+	}
+	assert.Equal(t, len(store.Articles()), total)
+
+	expectedYear := store.Archives()[0]
+	year := getAPI[model.ArchiveYear](t, fmt.Sprintf("/apis/v1/archives/%d", expectedYear.Year), http.StatusOK).Data
+	assert.Equal(t, expectedYear, year)
+
+	expectedMonth := expectedYear.Months[len(expectedYear.Months)-1]
+	month := getAPI[model.ArchiveMonth](t, fmt.Sprintf("/apis/v1/archives/%d/%d", expectedMonth.Year, expectedMonth.Month), http.StatusOK).Data
+	assert.Equal(t, expectedMonth, month)
+	// 归档日期即文章的更新日期
+	for _, article := range month.Articles {
+		assert.Equal(t, store.Article(article.ID).UpdatedAt, article.Date)
+		assert.True(t, strings.HasPrefix(article.Date, fmt.Sprintf("%d-%02d-", month.Year, month.Month)), article.Date)
+	}
+
+	assert.Equal(t, `invalid month "x"`, getAPI[any](t, fmt.Sprintf("/apis/v1/archives/%d/x", year.Year), http.StatusBadRequest).Message)
+	assert.Equal(t, "archive not found", getAPI[any](t, fmt.Sprintf("/apis/v1/archives/%d", emptyYear(store)), http.StatusNotFound).Message)
+}
+
+func TestSitemapArchives(t *testing.T) {
+	body := getBody(t, "/sitemap.xml", http.StatusOK)
+	assert.Contains(t, body, "<url><loc>https://www.narasux.cn/archives</loc></url>")
+	assert.Contains(t, body, "<url><loc>https://www.narasux.cn"+handler.TagPath(storage.Current().Tags()[0].Name)+"</loc></url>")
+	for _, year := range storage.Current().Archives() {
+		// 年份页的 lastmod 为该年最新文章的日期
+		assert.Contains(t, body, fmt.Sprintf("<url><loc>https://www.narasux.cn%s</loc><lastmod>%s</lastmod></url>",
+			handler.ArchivePath(year.Year, 0), year.Months[0].Articles[0].Date))
+		for _, month := range year.Months {
+			assert.Contains(t, body, fmt.Sprintf("<url><loc>https://www.narasux.cn%s</loc><lastmod>%s</lastmod></url>",
+				handler.ArchivePath(month.Year, month.Month), month.LastDate()))
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package router
+
+import (
+	"html/template"
+	"strings"
+
+	"github.com/Masterminds/sprig/v3"
+
+	"github.com/narasux/goblog/pkg/assets"
+	"github.com/narasux/goblog/pkg/handler"
+)
+
+// 模板方法
+func templateFuncMap() template.FuncMap {
+	funcMap := sprig.FuncMap()
+	funcMap["static"] = staticURL
+	funcMap["safeHTML"] = safeHTML
+	funcMap["categoryURL"] = handler.CategoryPath
+	funcMap["tagURL"] = handler.TagPath
+	funcMap["archiveURL"] = handler.ArchivePath
+	return funcMap
+}
+
+// safeHTML 标记内容为可信的 HTML（如 markdown 渲染结果），模板不再转义
+func safeHTML(content string) template.HTML {
+	return template.HTML(content)
+}
+
+// staticURL 生成带指纹的静态文件 URL，如 /static/js/axios.min.js?v=1a2b3c4d5e，
+// 文件内容变化时 URL 随之变化，因此浏览器可以放心地长期缓存
+func staticURL(name string) string {
+	name = strings.TrimPrefix(name, "/")
+	url := "/static/" + name
+
+	fingerprint := assets.StaticFingerprint(name)
+	if fingerprint == "" {
+		return url
+	}
+	return url + "?v=" + fingerprint
+}
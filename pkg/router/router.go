@@ -1,40 +1,82 @@
 package router
 
 import (
-	"fmt"
+	"html/template"
 
-	"github.com/Masterminds/sprig/v3"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 
+	"github.com/narasux/goblog/pkg/assets"
 	"github.com/narasux/goblog/pkg/envs"
 	"github.com/narasux/goblog/pkg/handler"
+	"github.com/narasux/goblog/pkg/infras/redis"
 	"github.com/narasux/goblog/pkg/middleware"
+	"github.com/narasux/goblog/pkg/ratelimit"
+	"github.com/narasux/goblog/pkg/utils/ginx"
 )
 
-func InitRouter() {
+// InitRouter 初始化路由并启动 web 服务
+func InitRouter() error {
+	router, err := New()
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(router.Run(":"+envs.ServerPort), "failed to start server")
+}
+
+// New 创建注册了所有路由的 gin.Engine（静态导出等场景下不启动服务，直接调用 ServeHTTP），
+// 可信代理配置有误时返回错误
+func New() (*gin.Engine, error) {
 	gin.SetMode(envs.GinRunMode)
 	router := gin.New()
-	_ = router.SetTrustedProxies(nil)
+	// 与 ginx.GetClientIP 使用相同的可信代理配置，同时在启动时校验配置合法性
+	if err := router.SetTrustedProxies(envs.TrustedProxies); err != nil {
+		return nil, errors.Wrap(err, "invalid trusted proxies")
+	}
+	if err := ginx.InitIPResolver(envs.TrustedProxies, envs.RealClientIPHeaderKey); err != nil {
+		return nil, errors.Wrap(err, "invalid trusted proxies")
+	}
 
 	router.Use(middleware.RequestID())
+	// 压缩需要在 Logger 之前，确保日志记录的是压缩前的内容
+	router.Use(middleware.Compress())
 	router.Use(middleware.Logger())
 	router.Use(middleware.Cors())
 	router.Use(gin.Recovery())
 
 	// 设置静态文件
-	router.Static("/static", envs.StaticFileBaseDir)
-	// 设置模板方法
-	router.SetFuncMap(sprig.FuncMap())
-	// 加载 HTML 模板文件
-	router.LoadHTMLGlob(envs.TmplFileBaseDir + "/webfe/*")
+	staticRg := router.Group("static")
+	staticRg.Use(middleware.StaticCacheControl(
+		envs.CacheControlStatic, envs.CacheControlFingerprintedStatic, assets.StaticFingerprint,
+	))
+	serveStatic := handler.ServeStatic(assets.Static())
+	staticRg.GET("*filepath", serveStatic)
+	staticRg.HEAD("*filepath", serveStatic)
+	// 文章图片的各尺寸版本（按需生成）
+	imagesRg := router.Group("images")
+	imagesRg.Use(middleware.StaticCacheControl(envs.CacheControlStatic, envs.CacheControlFingerprintedStatic, nil))
+	imagesRg.GET(":width/*filepath", handler.GetImageVariant)
+	// 加载 HTML 模板文件（同时设置模板方法）
+	router.SetHTMLTemplate(template.Must(
+		template.New("").Funcs(templateFuncMap()).ParseFS(assets.Templates(), "webfe/*"),
+	))
 	// 404
 	router.NoRoute(handler.Get404)
 	// robots.txt
 	router.GET("robots.txt", handler.GetRobotsTxt)
+	// sitemap.xml
+	router.GET("sitemap.xml", handler.GetSitemap)
+
+	// 限流状态存储
+	limitStore := newRateLimitStore()
 
 	// webfe 路由
 	{
 		webfeRg := router.Group("")
+		webfeRg.Use(middleware.RateLimit(
+			limitStore, "webfe", ratelimit.PerMinute(envs.RateLimitWebfePerMinute, envs.RateLimitWebfeBurst),
+		))
+		webfeRg.Use(middleware.CacheControl(envs.CacheControlWebfe))
 		// 主页
 		webfeRg.GET("", handler.GetHomePage)
 		webfeRg.GET("home", handler.GetHomePage)
@@ -42,6 +84,20 @@ func InitRouter() {
 		webfeRg.GET("articles", handler.ListArticles)
 		// 博客文章详情
 		webfeRg.GET("articles/:id", handler.RetrieveArticle)
+		// 分类 / 标签（列表页及各分类 / 标签下的文章列表）
+		webfeRg.GET("categories", handler.GetCategories)
+		webfeRg.GET("categories/:name", handler.ListCategoryArticles)
+		webfeRg.GET("tags", handler.GetTags)
+		webfeRg.GET("tags/:name", handler.ListTagArticles)
+		// 按更新日期归档
+		webfeRg.GET("archives", handler.GetArchives)
+		webfeRg.GET("archives/:year", handler.GetYearArchives)
+		webfeRg.GET("archives/:year/:month", handler.GetMonthArchives)
+		// 博客文章的社交分享预览图（/og/:id.png）
+		webfeRg.GET("og/:file", handler.GetOGImage)
+		// 文章系列
+		webfeRg.GET("series/:id", handler.GetSeries)
+		webfeRg.GET("series/:id/rss", handler.GetSeriesRSS)
 		// 软件系统设计元素周期表
 		webfeRg.GET("periodic-table", handler.GetPeriodicTable)
 		// RSS
@@ -51,11 +107,35 @@ func InitRouter() {
 	// api 路由
 	{
 		apiRg := router.Group("apis")
+		apiRg.Use(middleware.RateLimit(
+			limitStore, "apis", ratelimit.PerMinute(envs.RateLimitAPIPerMinute, envs.RateLimitAPIBurst),
+		))
+		apiRg.Use(middleware.CacheControl(envs.CacheControlAPI))
 		// 点赞博客文章
 		apiRg.POST("articles/:id/like", handler.LikeArticle)
+		// 博客文章详情 / 目录（已废弃，仅为兼容旧客户端保留，请使用 v1 文章详情）
+		apiRg.GET("articles/:id", handler.GetArticleDetail)
+		apiRg.GET("articles/:id/toc", handler.GetArticleTOC)
+
+		// v1 API（只读，供移动端，命令行等客户端使用，文档见 /apis/v1/openapi.json）
+		v1Rg := apiRg.Group("v1")
+		v1Rg.GET("openapi.json", handler.GetOpenAPIDoc)
+		v1Rg.GET("articles", handler.ListArticlesV1)
+		v1Rg.GET("articles/:id", handler.RetrieveArticleV1)
+		v1Rg.GET("categories", handler.ListCategoriesV1)
+		v1Rg.GET("tags", handler.ListTagsV1)
+		v1Rg.GET("archives", handler.ListArchivesV1)
+		v1Rg.GET("archives/:year", handler.RetrieveYearArchivesV1)
+		v1Rg.GET("archives/:year/:month", handler.RetrieveMonthArchivesV1)
 	}
 
-	if err := router.Run(":" + envs.ServerPort); err != nil {
-		panic(fmt.Sprintf("failed to start server: %s", err.Error()))
+	return router, nil
+}
+
+// 根据配置创建限流状态存储
+func newRateLimitStore() ratelimit.Store {
+	if envs.RateLimitStore == ratelimit.StoreTypeRedis {
+		return ratelimit.NewRedisStore(redis.Client(), "goblog:ratelimit:")
 	}
+	return ratelimit.NewMemoryStore()
 }
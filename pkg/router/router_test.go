@@ -0,0 +1,253 @@
+package router_test
+
+import (
+	"encoding/json"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+
+	"github.com/narasux/goblog/pkg/assets"
+	"github.com/narasux/goblog/pkg/envs"
+	"github.com/narasux/goblog/pkg/router"
+	"github.com/narasux/goblog/pkg/storage"
+)
+
+func TestMain(m *testing.M) {
+	storage.InitBlogData()
+	os.Exit(m.Run())
+}
+
+// 创建路由（配置有误时测试失败）
+func newEngine(t *testing.T) *gin.Engine {
+	engine, err := router.New()
+	assert.Nil(t, err)
+	return engine
+}
+
+func TestNewInvalidTrustedProxies(t *testing.T) {
+	trustedProxies := envs.TrustedProxies
+	t.Cleanup(func() { envs.TrustedProxies = trustedProxies })
+
+	// 可信代理配置有误时，启动阶段直接返回错误，而不是在处理请求时 panic
+	envs.TrustedProxies = []string{"not-an-ip"}
+	_, err := router.New()
+	assert.NotNil(t, err)
+}
+
+// 页面 <head> 中的 SEO 相关标签
+type headTags struct {
+	title     string
+	canonical string
+	// meta 标签（name / property -> content，同名标签的值按顺序保存）
+	metas  map[string][]string
+	jsonLD []map[string]any
+}
+
+func (h headTags) meta(key string) string {
+	if values := h.metas[key]; len(values) != 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func parseHead(t *testing.T, body string) headTags {
+	doc, err := html.Parse(strings.NewReader(body))
+	assert.Nil(t, err)
+
+	tags := headTags{metas: map[string][]string{}}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			attrs := map[string]string{}
+			for _, attr := range n.Attr {
+				attrs[attr.Key] = attr.Val
+			}
+			switch {
+			case n.Data == "title" && n.FirstChild != nil:
+				tags.title = n.FirstChild.Data
+			case n.Data == "link" && attrs["rel"] == "canonical":
+				tags.canonical = attrs["href"]
+			case n.Data == "meta" && (attrs["name"] != "" || attrs["property"] != ""):
+				key := attrs["name"] + attrs["property"]
+				tags.metas[key] = append(tags.metas[key], attrs["content"])
+			case n.Data == "script" && attrs["type"] == "application/ld+json" && n.FirstChild != nil:
+				var data map[string]any
+				assert.Nil(t, json.Unmarshal([]byte(n.FirstChild.Data), &data))
+				tags.jsonLD = append(tags.jsonLD, data)
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+	return tags
+}
+
+func get(t *testing.T, path string, expectedStatus int) headTags {
+	w := httptest.NewRecorder()
+	newEngine(t).ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, expectedStatus, w.Code, path)
+	return parseHead(t, w.Body.String())
+}
+
+func TestArticleSEO(t *testing.T) {
+	article := storage.Current().Article("scaling-in-kubernetes")
+	tags := get(t, "/articles/scaling-in-kubernetes", http.StatusOK)
+	url := "https://www.narasux.cn/articles/scaling-in-kubernetes"
+
+	assert.Equal(t, article.Title+" - Narasux Blogs", tags.title)
+	assert.Equal(t, url, tags.canonical)
+	assert.Equal(t, article.Desc, tags.meta("description"))
+
+	assert.Equal(t, "Narasux Blogs", tags.meta("og:site_name"))
+	assert.Equal(t, "article", tags.meta("og:type"))
+	assert.Equal(t, url, tags.meta("og:url"))
+	assert.Equal(t, article.Title+" - Narasux Blogs", tags.meta("og:title"))
+	assert.Equal(t, article.Desc, tags.meta("og:description"))
+	// 没有自定义封面图时，使用自动生成的预览图
+	assert.Equal(t, "https://www.narasux.cn/og/scaling-in-kubernetes.png", tags.meta("og:image"))
+	assert.Equal(t, "1200", tags.meta("og:image:width"))
+	assert.Equal(t, article.UpdatedAt, tags.meta("article:modified_time"))
+	assert.Equal(t, article.Category, tags.meta("article:section"))
+	assert.Equal(t, article.Tags, tags.metas["article:tag"])
+
+	assert.Equal(t, "summary_large_image", tags.meta("twitter:card"))
+	assert.Equal(t, article.Title+" - Narasux Blogs", tags.meta("twitter:title"))
+	assert.Equal(t, article.Desc, tags.meta("twitter:description"))
+	assert.Equal(t, tags.meta("og:image"), tags.meta("twitter:image"))
+
+	assert.Len(t, tags.jsonLD, 1)
+	ld := tags.jsonLD[0]
+	assert.Equal(t, "https://schema.org", ld["@context"])
+	assert.Equal(t, "BlogPosting", ld["@type"])
+	assert.Equal(t, article.Title, ld["headline"])
+	assert.Equal(t, article.UpdatedAt, ld["datePublished"])
+	assert.Equal(t, article.UpdatedAt, ld["dateModified"])
+	assert.Equal(t, strings.Join(article.Tags, ", "), ld["keywords"])
+	assert.Equal(t, float64(article.WordCount), ld["wordCount"])
+	assert.Equal(t, url, ld["url"])
+}
+
+func TestPageSEO(t *testing.T) {
+	home := get(t, "/", http.StatusOK)
+	assert.Equal(t, "Narasux Blogs", home.title)
+	assert.Equal(t, "https://www.narasux.cn/", home.canonical)
+	assert.Equal(t, "website", home.meta("og:type"))
+	assert.Len(t, home.jsonLD, 1)
+	assert.Equal(t, "WebSite", home.jsonLD[0]["@type"])
+
+	list := get(t, "/articles?tag=K8s", http.StatusOK)
+	assert.Equal(t, "标签：K8s - Narasux Blogs", list.title)
+	// 规范链接为标签页
+	assert.Equal(t, "https://www.narasux.cn/tags/K8s", list.canonical)
+	assert.Empty(t, list.jsonLD)
+
+	notFound := get(t, "/articles/not-exists", http.StatusNotFound)
+	assert.Equal(t, "404 Not Found - Narasux Blogs", notFound.title)
+	assert.Equal(t, "noindex", notFound.meta("robots"))
+	assert.Equal(t, "Narasux Blogs", notFound.meta("og:site_name"))
+	assert.Empty(t, notFound.canonical)
+}
+
+func TestOGImage(t *testing.T) {
+	engine := newEngine(t)
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/og/scaling-in-kubernetes.png", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	img, err := png.Decode(w.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, 1200, img.Bounds().Dx())
+
+	// 缓存校验
+	req := httptest.NewRequest(http.MethodGet, "/og/scaling-in-kubernetes.png", nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	for _, path := range []string{"/og/not-exists.png", "/og/scaling-in-kubernetes"} {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+}
+
+func TestImageVariant(t *testing.T) {
+	envs.ImageCacheDir = t.TempDir()
+	engine := newEngine(t)
+
+	// 文章中的图片在加载时已解析
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/articles/debug-in-django-3-2-to-4-2", nil))
+	assert.Contains(t, w.Body.String(), `srcset="/images/480/image/blog/which_middleware_cause_302.png.webp 480w`)
+	assert.Contains(t, w.Body.String(), `width="886" height="102"`)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/images/480/image/blog/which_middleware_cause_302.png", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	img, err := png.Decode(w.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, 480, img.Bounds().Dx())
+
+	// 客户端缓存有效时不再读取 / 生成图片
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	req := httptest.NewRequest(http.MethodGet, "/images/480/image/blog/which_middleware_cause_302.png", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	for _, path := range []string{
+		"/images/123/image/blog/which_middleware_cause_302.png",
+		"/images/abc/image/blog/which_middleware_cause_302.png",
+		"/images/480/image/blog/not-exists.png",
+	} {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+}
+
+func TestStaticCacheControl(t *testing.T) {
+	engine := newEngine(t)
+	fingerprint := assets.StaticFingerprint("js/axios.min.js")
+	assert.NotEmpty(t, fingerprint)
+
+	// 只有 ?v= 与文件当前的指纹一致时才长期缓存
+	for query, expected := range map[string]string{
+		"?v=" + fingerprint: envs.CacheControlFingerprintedStatic,
+		"?v=0123456789":     envs.CacheControlStatic,
+		"":                  envs.CacheControlStatic,
+	} {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static/js/axios.min.js"+query, nil))
+		assert.Equal(t, http.StatusOK, w.Code, query)
+		assert.Equal(t, expected, w.Header().Get("Cache-Control"), query)
+	}
+}
+
+func TestStaticCompression(t *testing.T) {
+	engine := newEngine(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/static/js/axios.min.js", nil)
+	req.Header.Set("Accept-Encoding", "gzip, br")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "br", w.Header().Get("Content-Encoding"))
+	// 压缩中间件与静态文件 handler 都会添加 Vary，不能重复
+	assert.Equal(t, []string{"Accept-Encoding"}, w.Header().Values("Vary"))
+}
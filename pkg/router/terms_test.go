@@ -0,0 +1,128 @@
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+
+	"github.com/narasux/goblog/pkg/handler"
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/storage"
+)
+
+func getBody(t *testing.T, path string, expectedStatus int) string {
+	w := httptest.NewRecorder()
+	newEngine(t).ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, expectedStatus, w.Code, path)
+	return w.Body.String()
+}
+
+// 页面中的链接
+type anchor struct {
+	href    string
+	title   string
+	text    string
+	classes []string
+}
+
+// parseAnchors 解析页面中的链接，id 不为空时只解析该元素内的链接
+func parseAnchors(t *testing.T, body, id string) []anchor {
+	doc, err := html.Parse(strings.NewReader(body))
+	assert.Nil(t, err)
+
+	var anchors []anchor
+	var walk func(n *html.Node, inScope bool)
+	walk = func(n *html.Node, inScope bool) {
+		if n.Type == html.ElementNode {
+			attrs := map[string]string{}
+			for _, attr := range n.Attr {
+				attrs[attr.Key] = attr.Val
+			}
+			inScope = inScope || attrs["id"] == id
+			if inScope && n.Data == "a" {
+				a := anchor{href: attrs["href"], title: attrs["title"], classes: strings.Fields(attrs["class"])}
+				if n.FirstChild != nil {
+					a.text = strings.TrimSpace(n.FirstChild.Data)
+				}
+				anchors = append(anchors, a)
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child, inScope)
+		}
+	}
+	walk(doc, id == "")
+	return anchors
+}
+
+func hrefs(anchors []anchor) []string {
+	return lo.Map(anchors, func(a anchor, _ int) string { return a.href })
+}
+
+func TestCategoriesPage(t *testing.T) {
+	categories := storage.Current().Categories()
+	terms := parseAnchors(t, getBody(t, "/categories", http.StatusOK), "terms")
+	assert.Len(t, terms, len(categories))
+	for idx, category := range categories {
+		assert.Equal(t, category.Name, terms[idx].text)
+		assert.Equal(t, handler.CategoryPath(category.Name), terms[idx].href)
+	}
+	assert.Contains(t, hrefs(terms), "/categories/%E6%8A%80%E6%9C%AF%E5%88%86%E4%BA%AB")
+
+	head := parseHead(t, getBody(t, "/categories/技术分享", http.StatusOK))
+	assert.Equal(t, "分类：技术分享 - Narasux Blogs", head.title)
+	assert.Equal(t, "https://www.narasux.cn/categories/%E6%8A%80%E6%9C%AF%E5%88%86%E4%BA%AB", head.canonical)
+
+	getBody(t, "/categories/not-exists", http.StatusNotFound)
+}
+
+func TestTagsPage(t *testing.T) {
+	tags := storage.Current().Tags()
+	body := getBody(t, "/tags", http.StatusOK)
+
+	// 标签云按名称排序（忽略大小写），文章最多的标签字号最大，最少的最小
+	cloud := parseAnchors(t, body, "tag-cloud")
+	assert.Len(t, cloud, len(tags))
+	assert.True(t, slices.IsSortedFunc(cloud, func(a, b anchor) int {
+		return strings.Compare(strings.ToLower(a.text), strings.ToLower(b.text))
+	}))
+	for _, item := range cloud {
+		tag, ok := lo.Find(tags, func(tag model.TermCount) bool { return tag.Name == item.text })
+		assert.True(t, ok, item.text)
+		assert.Equal(t, handler.TagPath(tag.Name), item.href)
+		assert.Equal(t, strconv.Itoa(tag.Count)+" 篇文章", item.title)
+		switch tag.Count {
+		case tags[0].Count:
+			assert.Contains(t, item.classes, "text-3xl", tag.Name)
+		case tags[len(tags)-1].Count:
+			assert.Contains(t, item.classes, "text-sm", tag.Name)
+		}
+	}
+	// 标签列表与 Tags() 的顺序一致（按文章数降序）
+	assert.Equal(t, lo.Map(tags, func(tag model.TermCount, _ int) string { return handler.TagPath(tag.Name) }),
+		hrefs(parseAnchors(t, body, "terms")))
+
+	list := getBody(t, "/tags/Kubernetes", http.StatusOK)
+	head := parseHead(t, list)
+	assert.Equal(t, "标签：Kubernetes - Narasux Blogs", head.title)
+	assert.Equal(t, "https://www.narasux.cn/tags/Kubernetes", head.canonical)
+	links := hrefs(parseAnchors(t, list, ""))
+	for _, article := range storage.Current().Articles() {
+		if slices.Contains(article.Tags, "Kubernetes") {
+			assert.Contains(t, links, "/articles/"+article.ID)
+		} else {
+			assert.NotContains(t, links, "/articles/"+article.ID)
+		}
+	}
+	// 查询参数形式的过滤仍然可用
+	assert.Equal(t, head.canonical, parseHead(t, getBody(t, "/articles?tag=Kubernetes", http.StatusOK)).canonical)
+
+	getBody(t, "/tags/not-exists", http.StatusNotFound)
+}
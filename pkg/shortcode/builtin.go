@@ -0,0 +1,150 @@
+package shortcode
+
+import (
+	"html/template"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/utils/markdownx"
+)
+
+func init() {
+	Register("article", renderArticle)
+	Register("element", renderElement)
+	Register("gist", renderGist)
+	Register("video", renderVideo)
+}
+
+// 短代码输出的 html 模板（需要保持单行），除 article 外均为块级 html，只能单独占一行使用
+var (
+	articleTmpl = template.Must(template.New("article").Parse(
+		`<a class="text-blue-500" href="/articles/{{ .ID }}" title="{{ .Desc }}">{{ .Text }}</a>`,
+	))
+	elementTmpl = template.Must(template.New("element").Parse(
+		`<div class="my-4 mx-2 flex items-center gap-4 rounded-xl bg-white p-4 shadow-md">` +
+			`<a class="bg-{{ .Group.Color }} flex h-16 w-16 flex-none items-center justify-center rounded-md ` +
+			`border border-stone-600 font-light" href="/periodic-table" title="软件设计元素周期表">{{ .Element.Symbol }}</a>` +
+			`<div><p class="font-bold">{{ .Element.Name }}</p>` +
+			`<p class="text-sm text-gray-600">{{ .Element.Description }}</p>` +
+			`<p class="mt-1 text-xs text-gray-500">所属族：{{ .Group.Name }}</p></div></div>`,
+	))
+	gistTmpl = template.Must(template.New("gist").Parse(
+		`<div class="my-4 mx-2"><script src="https://gist.github.com/{{ .ID }}.js` +
+			`{{ with .File }}?file={{ . }}{{ end }}"></script></div>`,
+	))
+	videoTmpl = template.Must(template.New("video").Parse(
+		`<figure class="my-6 mx-2">{{ if .Src }}` +
+			`<video class="mx-auto max-w-full" src="{{ .Src }}" title="{{ .Title }}" controls preload="metadata"></video>` +
+			`{{ else }}<div class="aspect-video"><iframe class="h-full w-full" src="{{ .Embed }}" title="{{ .Title }}" ` +
+			`loading="lazy" allowfullscreen></iframe></div>{{ end }}` +
+			`{{ with .Caption }}<figcaption class="mt-2 text-center text-sm text-gray-500">{{ . }}</figcaption>{{ end }}` +
+			`</figure>`,
+	))
+)
+
+var (
+	gistIDRegex    = regexp.MustCompile(`^[\w-]+/[0-9a-f]+$`)
+	youtubeIDRegex = regexp.MustCompile(`^[\w-]{11}$`)
+	bvidRegex      = regexp.MustCompile(`^BV[0-9A-Za-z]{10}$`)
+)
+
+// renderArticle 站内文章链接，标题取自文章元数据：{{< article id="xxx" [text="链接文字"] >}}
+func renderArticle(data *model.BlogData, sc markdownx.Shortcode) (string, error) {
+	id, err := sc.Arg("id")
+	if err != nil {
+		return "", err
+	}
+	article := data.Articles.GetByID(id)
+	if article == nil {
+		return "", errors.Errorf("article %s not found", id)
+	}
+	text := sc.Args["text"]
+	if text == "" {
+		text = article.Title
+	}
+	return execute(articleTmpl, map[string]string{"ID": article.ID, "Desc": article.Desc, "Text": text})
+}
+
+// renderElement 软件设计元素周期表中的元素卡片：{{< element symbol="Si" >}}
+func renderElement(data *model.BlogData, sc markdownx.Shortcode) (string, error) {
+	if err := requireBlock(sc); err != nil {
+		return "", err
+	}
+	symbol, err := sc.Arg("symbol")
+	if err != nil {
+		return "", err
+	}
+	if data.PeriodicTable == nil {
+		return "", errors.New("periodic table not loaded")
+	}
+	element, group := data.PeriodicTable.GetElement(symbol)
+	if element == nil {
+		return "", errors.Errorf("element %s not found", symbol)
+	}
+	return execute(elementTmpl, map[string]any{"Element": element, "Group": group})
+}
+
+// renderGist GitHub Gist：{{< gist id="user/0123abcd" [file="main.go"] >}}
+func renderGist(_ *model.BlogData, sc markdownx.Shortcode) (string, error) {
+	if err := requireBlock(sc); err != nil {
+		return "", err
+	}
+	id, err := sc.Arg("id")
+	if err != nil {
+		return "", err
+	}
+	if !gistIDRegex.MatchString(id) {
+		return "", errors.Errorf("invalid gist id %s, should be like user/0123abcd", id)
+	}
+	return execute(gistTmpl, map[string]string{"ID": id, "File": sc.Args["file"]})
+}
+
+// renderVideo 视频，支持视频文件及 YouTube / Bilibili 嵌入（三选一）：
+// {{< video src="/static/video/a.mp4" >}}，{{< video youtube="dQw4w9WgXcQ" >}}，{{< video bilibili="BV1xx411c7mD" >}}，
+// 可选参数 caption 为视频说明
+func renderVideo(_ *model.BlogData, sc markdownx.Shortcode) (string, error) {
+	if err := requireBlock(sc); err != nil {
+		return "", err
+	}
+	src, youtube, bilibili := sc.Args["src"], sc.Args["youtube"], sc.Args["bilibili"]
+	params := map[string]string{"Src": src, "Title": "视频", "Caption": sc.Args["caption"]}
+	if params["Caption"] != "" {
+		params["Title"] = params["Caption"]
+	}
+
+	switch {
+	case len(lo.Compact([]string{src, youtube, bilibili})) != 1:
+		return "", errors.New("exactly one of src, youtube and bilibili is required")
+	case youtube != "":
+		if !youtubeIDRegex.MatchString(youtube) {
+			return "", errors.Errorf("invalid youtube video id %s", youtube)
+		}
+		params["Embed"] = "https://www.youtube-nocookie.com/embed/" + youtube
+	case bilibili != "":
+		if !bvidRegex.MatchString(bilibili) {
+			return "", errors.Errorf("invalid bilibili bvid %s", bilibili)
+		}
+		params["Embed"] = "https://player.bilibili.com/player.html?bvid=" + bilibili + "&autoplay=0"
+	}
+	return execute(videoTmpl, params)
+}
+
+// requireBlock 输出块级 html 的短代码需要单独占一行，否则会被包裹在段落中（如 <p><div>）
+func requireBlock(sc markdownx.Shortcode) error {
+	if !sc.Block {
+		return errors.New("must be on its own line")
+	}
+	return nil
+}
+
+func execute(tmpl *template.Template, data any) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,36 @@
+// Package shortcode 文章 markdown 中可用的短代码（如 {{< article id="xxx" >}}），处理函数在此注册
+package shortcode
+
+import (
+	"fmt"
+
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/utils/markdownx"
+)
+
+// Handler 短代码处理函数，返回替换短代码的 html
+//
+// data 为正在加载的博客数据（加载完成后用于创建 storage.Store），文章元数据及元素周期表在文章内容之前加载
+type Handler func(data *model.BlogData, sc markdownx.Shortcode) (string, error)
+
+// 已注册的短代码（名称 -> 处理函数）
+var handlers = map[string]Handler{}
+
+// Register 注册短代码，名称重复时 panic
+func Register(name string, handler Handler) {
+	if _, ok := handlers[name]; ok {
+		panic(fmt.Sprintf("shortcode %s already registered", name))
+	}
+	handlers[name] = handler
+}
+
+// Shortcodes 绑定博客数据，生成渲染 markdown 使用的短代码处理函数
+func Shortcodes(data *model.BlogData) markdownx.Shortcodes {
+	shortcodes := make(markdownx.Shortcodes, len(handlers))
+	for name, handler := range handlers {
+		shortcodes[name] = func(sc markdownx.Shortcode) (string, error) {
+			return handler(data, sc)
+		}
+	}
+	return shortcodes
+}
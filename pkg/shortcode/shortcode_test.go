@@ -0,0 +1,101 @@
+package shortcode_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/shortcode"
+	"github.com/narasux/goblog/pkg/utils/markdownx"
+)
+
+var testData = &model.BlogData{
+	Articles: model.Articles{{ID: "a", Title: "文章 <A>", Desc: "描述"}},
+	PeriodicTable: &model.ElementPeriodicTable{Groups: []model.ElementGroup{{
+		Symbol: "Str", Name: "结构设计", Color: "rose-200",
+		Elements: []model.Element{{Symbol: "Si", Name: "简单性", Description: "保持简单"}},
+	}}},
+}
+
+func expand(t *testing.T, content string) (string, error) {
+	t.Helper()
+	expanded, err := markdownx.ExpandShortcodes("a.md", []byte(content), shortcode.Shortcodes(testData))
+	return string(expanded), err
+}
+
+func TestArticle(t *testing.T) {
+	html, err := expand(t, `见 {{< article id="a" >}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, `见 <a class="text-blue-500" href="/articles/a" title="描述">文章 &lt;A&gt;</a>`, html)
+
+	html, err = expand(t, `见 {{< article id="a" text="这里" >}}`)
+	assert.Nil(t, err)
+	assert.Contains(t, html, `>这里</a>`)
+
+	_, err = expand(t, `{{< article id="b" >}}`)
+	assert.EqualError(t, err, "a.md:1: shortcode article: article b not found")
+}
+
+func TestElement(t *testing.T) {
+	html, err := expand(t, `{{< element symbol="Si" >}}`)
+	assert.Nil(t, err)
+	assert.Contains(t, html, `<a class="bg-rose-200 `)
+	assert.Contains(t, html, `>Si</a><div><p class="font-bold">简单性</p>`)
+	assert.Contains(t, html, `所属族：结构设计`)
+
+	_, err = expand(t, `{{< element symbol="Xx" >}}`)
+	assert.EqualError(t, err, "a.md:1: shortcode element: element Xx not found")
+}
+
+func TestBlockShortcodes(t *testing.T) {
+	// 输出块级 html 的短代码不能在段落或列表项中使用，否则会出现 <p><div>
+	for _, content := range []string{
+		`见 {{< element symbol="Si" >}}`,
+		`- {{< gist id="narasux/0123abcd" >}}`,
+		`  {{< video youtube="dQw4w9WgXcQ" >}}`,
+	} {
+		_, err := expand(t, content)
+		assert.ErrorContains(t, err, "must be on its own line", content)
+	}
+
+	html, err := expand(t, "段落\n{{< element symbol=\"Si\" >}}\n段落")
+	assert.Nil(t, err)
+	rendered := markdownx.ToHTML([]byte(html))
+	assert.Contains(t, rendered, `<div class="my-4 mx-2 flex`)
+	assert.NotRegexp(t, `<p[^>]*><div`, rendered)
+}
+
+func TestGist(t *testing.T) {
+	html, err := expand(t, `{{< gist id="narasux/0123abcd" file="main.go" >}}`)
+	assert.Nil(t, err)
+	assert.Contains(t, html, `<script src="https://gist.github.com/narasux/0123abcd.js?file=main.go"></script>`)
+
+	_, err = expand(t, `{{< gist id="../evil" >}}`)
+	assert.NotNil(t, err)
+}
+
+func TestVideo(t *testing.T) {
+	html, err := expand(t, `{{< video src="/static/video/a.mp4" caption="演示" >}}`)
+	assert.Nil(t, err)
+	assert.Contains(t, html, `<video class="mx-auto max-w-full" src="/static/video/a.mp4" title="演示" controls`)
+	assert.Contains(t, html, `<figcaption class="mt-2 text-center text-sm text-gray-500">演示</figcaption>`)
+
+	html, err = expand(t, `{{< video youtube="dQw4w9WgXcQ" >}}`)
+	assert.Nil(t, err)
+	assert.Contains(t, html, `src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"`)
+
+	html, err = expand(t, `{{< video bilibili="BV1xx411c7mD" >}}`)
+	assert.Nil(t, err)
+	assert.Contains(t, html, `src="https://player.bilibili.com/player.html?bvid=BV1xx411c7mD&amp;autoplay=0"`)
+
+	for _, content := range []string{
+		`{{< video >}}`,
+		`{{< video src="/a.mp4" youtube="dQw4w9WgXcQ" >}}`,
+		`{{< video youtube="x" >}}`,
+		`{{< video bilibili="av123" >}}`,
+	} {
+		_, err = expand(t, content)
+		assert.NotNil(t, err, content)
+	}
+}
@@ -2,24 +2,37 @@ package storage
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/narasux/goblog/pkg/loader"
-	"github.com/narasux/goblog/pkg/model"
 )
 
-var BlogData *model.BlogData
+// 当前的博客数据，替换时原子地切换到新的 Store，正在处理的请求继续使用旧的 Store
+var current atomic.Pointer[Store]
 
 var initOnce sync.Once
 
-// InitBlogData 加载并初始化博客数据
+// InitBlogData 加载博客数据并建立索引
 func InitBlogData() {
-	if BlogData != nil {
+	if current.Load() != nil {
 		return
 	}
 	initOnce.Do(func() {
-		var err error
-		if BlogData, err = loader.New().Exec(); err != nil {
+		l := loader.New()
+		data, err := l.Exec()
+		if err != nil {
 			panic(err)
 		}
+		Replace(NewStore(data, l.Images()))
 	})
 }
+
+// Current 获取当前的博客数据（并发安全），同一请求中应只获取一次，以免前后读到不同版本的数据
+func Current() *Store {
+	return current.Load()
+}
+
+// Replace 原子地替换当前的博客数据，返回被替换的 Store
+func Replace(store *Store) *Store {
+	return current.Swap(store)
+}
@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/narasux/goblog/pkg/images"
+	"github.com/narasux/goblog/pkg/model"
+)
+
+// Store 博客数据及其索引（按 ID / 分类 / 标签 / 日期），创建后不再修改，可被多个请求并发读取；
+// 返回的文章，系列，归档等均为深拷贝，调用方可以任意修改，不会影响 Store 及其他请求
+type Store struct {
+	data model.BlogData
+	// 文章中已解析的图片（用于生成各尺寸版本），与博客数据一同替换
+	images *images.Registry
+	// 所有文章（按日期降序，日期相同时保持 articles.json 中的顺序）
+	articles model.Articles
+
+	// 文章 ID -> 文章在 articles 中的下标
+	articleIdx map[string]int
+	// 分类 / 标签 -> 文章（顺序与 articles 一致）
+	categoryIdx map[string]model.Articles
+	tagIdx      map[string]model.Articles
+	// 各分类 / 标签的文章数（按文章数降序）
+	categoryCounts []model.TermCount
+	tagCounts      []model.TermCount
+
+	// 年份 / 年月 -> 归档
+	yearIdx  map[int]*model.ArchiveYear
+	monthIdx map[archiveKey]*model.ArchiveMonth
+
+	// 系列 ID -> 系列在 data.Series 中的下标
+	seriesIdx map[string]int
+}
+
+type archiveKey struct {
+	year, month int
+}
+
+// NewStore 为博客数据建立索引（深拷贝 data，之后修改 data 不影响 Store）；
+// registry 为加载文章时解析的图片（参见 loader.BlogLoader.Images），为 nil 时没有可用的图片
+func NewStore(data *model.BlogData, registry *images.Registry) *Store {
+	if registry == nil {
+		registry = images.NewRegistry()
+	}
+	s := &Store{
+		data:        *data,
+		images:      registry,
+		articleIdx:  make(map[string]int, len(data.Articles)),
+		categoryIdx: map[string]model.Articles{},
+		tagIdx:      map[string]model.Articles{},
+		yearIdx:     make(map[int]*model.ArchiveYear, len(data.Archives)),
+		monthIdx:    map[archiveKey]*model.ArchiveMonth{},
+		seriesIdx:   make(map[string]int, len(data.Series)),
+	}
+
+	s.articles = data.Articles.Clone()
+	slices.SortStableFunc(s.articles, func(a, b model.Article) int {
+		return cmp.Compare(b.UpdatedAt, a.UpdatedAt)
+	})
+
+	for idx, article := range s.articles {
+		s.articleIdx[article.ID] = idx
+		s.categoryIdx[article.Category] = append(s.categoryIdx[article.Category], article)
+		// 同一篇文章中重复的标签只索引一次
+		for _, tag := range slices.Compact(slices.Sorted(slices.Values(article.Tags))) {
+			s.tagIdx[tag] = append(s.tagIdx[tag], article)
+		}
+	}
+	s.categoryCounts = s.articles.CountByCategory()
+	s.tagCounts = s.articles.CountByTag()
+
+	s.data.Categories = slices.Clone(data.Categories)
+	s.data.Tags = slices.Clone(data.Tags)
+	// 文章统一保存在 s.articles 中（已排序）
+	s.data.Articles = nil
+	s.data.Archives = data.Archives.Clone()
+	s.data.Series = data.Series.Clone()
+	s.data.Redirects = slices.Clone(data.Redirects)
+	s.data.PeriodicTable = data.PeriodicTable.Clone()
+	for i := range s.data.Archives {
+		year := &s.data.Archives[i]
+		s.yearIdx[year.Year] = year
+		for j := range year.Months {
+			s.monthIdx[archiveKey{year.Year, year.Months[j].Month}] = &year.Months[j]
+		}
+	}
+	for idx, series := range s.data.Series {
+		s.seriesIdx[series.ID] = idx
+	}
+	return s
+}
+
+// Articles 所有文章（按日期降序）
+func (s *Store) Articles() model.Articles {
+	return s.articles.Clone()
+}
+
+// Article 根据 ID 获取文章，不存在时返回 nil
+func (s *Store) Article(id string) *model.Article {
+	idx, ok := s.articleIdx[id]
+	if !ok {
+		return nil
+	}
+	return s.articles[idx].Clone()
+}
+
+// ArticlesByCategory 获取分类下的文章（按日期降序）
+func (s *Store) ArticlesByCategory(category string) model.Articles {
+	return s.categoryIdx[category].Clone()
+}
+
+// ArticlesByTag 获取标签下的文章（按日期降序）
+func (s *Store) ArticlesByTag(tag string) model.Articles {
+	return s.tagIdx[tag].Clone()
+}
+
+// FilterArticles 获取同时属于分类及标签的文章，参数为空表示不过滤
+func (s *Store) FilterArticles(category, tag string) model.Articles {
+	switch {
+	case category == "" && tag == "":
+		return s.Articles()
+	case tag == "":
+		return s.ArticlesByCategory(category)
+	case category == "":
+		return s.ArticlesByTag(tag)
+	}
+
+	var articles model.Articles
+	for idx := range s.tagIdx[tag] {
+		if article := &s.tagIdx[tag][idx]; article.Category == category {
+			articles = append(articles, *article.Clone())
+		}
+	}
+	return articles
+}
+
+// Categories 各分类的文章数（按文章数降序，相同时按名称升序）
+func (s *Store) Categories() []model.TermCount {
+	return slices.Clone(s.categoryCounts)
+}
+
+// Tags 各标签的文章数（按文章数降序，相同时按名称升序）
+func (s *Store) Tags() []model.TermCount {
+	return slices.Clone(s.tagCounts)
+}
+
+// Archives 按年 / 月的文章归档（年月均降序）
+func (s *Store) Archives() model.Archives {
+	return s.data.Archives.Clone()
+}
+
+// ArchiveYear 获取指定年份的归档，没有文章时返回 nil
+func (s *Store) ArchiveYear(year int) *model.ArchiveYear {
+	return s.yearIdx[year].Clone()
+}
+
+// ArchiveMonth 获取指定月份的归档，没有文章时返回 nil
+func (s *Store) ArchiveMonth(year, month int) *model.ArchiveMonth {
+	return s.monthIdx[archiveKey{year, month}].Clone()
+}
+
+// SeriesList 所有文章系列
+func (s *Store) SeriesList() model.SeriesList {
+	return s.data.Series.Clone()
+}
+
+// Series 根据 ID 获取系列，不存在时返回 nil
+func (s *Store) Series(id string) *model.Series {
+	idx, ok := s.seriesIdx[id]
+	if !ok {
+		return nil
+	}
+	return s.data.Series[idx].Clone()
+}
+
+// Redirects 重定向规则
+func (s *Store) Redirects() model.Redirects {
+	return slices.Clone(s.data.Redirects)
+}
+
+// Images 文章中已解析的图片
+func (s *Store) Images() *images.Registry {
+	return s.images
+}
+
+// PeriodicTable 元素周期表（加载失败时为 nil）及其内容哈希
+func (s *Store) PeriodicTable() (*model.ElementPeriodicTable, string) {
+	return s.data.PeriodicTable.Clone(), s.data.PeriodicTableETag
+}
+
+// ETag 所有文章的内容哈希，用于聚合页面（RSS，分类 / 标签 / 归档页等）的缓存校验
+func (s *Store) ETag() string {
+	return s.data.ETag
+}
+
+// LastModified 最新文章的更新时间
+func (s *Store) LastModified() time.Time {
+	return s.data.LastModified
+}
@@ -0,0 +1,264 @@
+package storage_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/model"
+	"github.com/narasux/goblog/pkg/storage"
+)
+
+func newTestStore() *storage.Store {
+	return storage.NewStore(&model.BlogData{
+		Articles: model.Articles{
+			{ID: "helm-crd", Category: "云原生", Tags: []string{"Helm"}, UpdatedAt: "2024-01-01"},
+			// 重复的标签只索引一次
+			{ID: "k8s-scaling", Category: "云原生", Tags: []string{"K8s", "HPA", "K8s"}, UpdatedAt: "2024-03-01"},
+			{ID: "go-leak", Category: "Golang", Tags: []string{"Go", "K8s"}, UpdatedAt: "2024-05-01"},
+			{ID: "k8s-vcluster", Category: "云原生", Tags: []string{"K8s"}, UpdatedAt: "2024-05-01"},
+		},
+		Series: model.SeriesList{{ID: "k8s", ArticleIDs: []string{"k8s-scaling", "k8s-vcluster"}}},
+		Archives: model.Archives{
+			{Year: 2024, Count: 4, Months: []model.ArchiveMonth{
+				{Year: 2024, Month: 5, Count: 2},
+				{Year: 2024, Month: 3, Count: 1},
+				{Year: 2024, Month: 1, Count: 1},
+			}},
+		},
+	}, nil)
+}
+
+func ids(articles model.Articles) []string {
+	result := make([]string, 0, len(articles))
+	for _, article := range articles {
+		result = append(result, article.ID)
+	}
+	return result
+}
+
+func TestStore(t *testing.T) {
+	store := newTestStore()
+
+	// 按日期降序，日期相同时保持原有顺序
+	assert.Equal(t, []string{"go-leak", "k8s-vcluster", "k8s-scaling", "helm-crd"}, ids(store.Articles()))
+
+	article := store.Article("k8s-scaling")
+	assert.Equal(t, "k8s-scaling", article.ID)
+	// 每次返回的都是副本
+	assert.NotSame(t, article, store.Article("k8s-scaling"))
+	assert.Equal(t, article, store.Article("k8s-scaling"))
+	assert.Nil(t, store.Article("not-exists"))
+
+	assert.Equal(t, []string{"k8s-vcluster", "k8s-scaling", "helm-crd"}, ids(store.ArticlesByCategory("云原生")))
+	assert.Equal(t, []string{"go-leak", "k8s-vcluster", "k8s-scaling"}, ids(store.ArticlesByTag("K8s")))
+	assert.Empty(t, store.ArticlesByTag("not-exists"))
+
+	assert.Len(t, store.FilterArticles("", ""), 4)
+	assert.Equal(t, []string{"go-leak"}, ids(store.FilterArticles("Golang", "")))
+	assert.Equal(t, []string{"k8s-vcluster", "k8s-scaling"}, ids(store.FilterArticles("云原生", "K8s")))
+	assert.Empty(t, store.FilterArticles("Golang", "Helm"))
+
+	assert.Equal(t, []model.TermCount{{Name: "云原生", Count: 3}, {Name: "Golang", Count: 1}}, store.Categories())
+	assert.Equal(t, model.TermCount{Name: "K8s", Count: 3}, store.Tags()[0])
+
+	assert.Equal(t, 4, store.ArchiveYear(2024).Count)
+	assert.Nil(t, store.ArchiveYear(2023))
+	assert.Equal(t, 2, store.ArchiveMonth(2024, 5).Count)
+	assert.Nil(t, store.ArchiveMonth(2024, 4))
+
+	assert.Equal(t, "k8s", store.Series("k8s").ID)
+	assert.Nil(t, store.Series("not-exists"))
+}
+
+func TestStoreViews(t *testing.T) {
+	data := &model.BlogData{
+		Articles: model.Articles{
+			{
+				ID: "a", Title: "A", Category: "C", Tags: []string{"T"}, UpdatedAt: "2024-02-01",
+				TOC:     []model.TOCItem{{Level: 1, Text: "H1", Children: []model.TOCItem{{Level: 2, Text: "H2"}}}},
+				Related: []model.ArticleLink{{ID: "b", Title: "B"}},
+				Next:    &model.ArticleLink{ID: "b", Title: "B"},
+				Series:  &model.SeriesNav{ID: "s", Articles: []model.ArticleLink{{ID: "a", Title: "A"}}},
+			},
+			{ID: "b", Title: "B", Category: "C", Tags: []string{"T"}, UpdatedAt: "2024-01-01"},
+		},
+		Series: model.SeriesList{{ID: "s", ArticleIDs: []string{"a"}}},
+		Archives: model.Archives{{Year: 2024, Count: 2, Months: []model.ArchiveMonth{
+			{Year: 2024, Month: 2, Count: 1, Articles: []model.ArchiveArticle{{ID: "a", Title: "A"}}},
+		}}},
+	}
+	store := storage.NewStore(data, nil)
+	expected := storage.NewStore(data, nil)
+
+	// 修改返回的文章（包括其中的切片及指针字段）不影响 Store
+	article := store.Article("a")
+	article.Title = "changed"
+	article.Tags[0] = "changed"
+	article.TOC[0].Children[0].Text = "changed"
+	article.Related[0].Title = "changed"
+	article.Next.Title = "changed"
+	article.Series.Articles[0].Title = "changed"
+
+	// 修改返回的切片不影响 Store
+	for _, articles := range []model.Articles{
+		store.Articles(), store.ArticlesByCategory("C"), store.ArticlesByTag("T"), store.FilterArticles("C", "T"),
+	} {
+		articles[0].Title = "changed"
+		articles[0].Tags[0] = "changed"
+		_ = append(articles[:1], model.Article{ID: "appended"})
+	}
+	store.Tags()[0].Count = 100
+	store.Categories()[0].Name = "changed"
+	store.Series("s").ArticleIDs[0] = "changed"
+	store.SeriesList()[0].ID = "changed"
+	store.Archives()[0].Months[0].Articles[0].Title = "changed"
+	store.ArchiveYear(2024).Months[0].Count = 100
+	store.ArchiveMonth(2024, 2).Articles[0].ID = "changed"
+
+	assert.Equal(t, expected.Article("a"), store.Article("a"))
+	assert.Equal(t, expected.Articles(), store.Articles())
+	assert.Equal(t, expected.ArticlesByCategory("C"), store.ArticlesByCategory("C"))
+	assert.Equal(t, expected.ArticlesByTag("T"), store.ArticlesByTag("T"))
+	assert.Equal(t, expected.Tags(), store.Tags())
+	assert.Equal(t, expected.Categories(), store.Categories())
+	assert.Equal(t, expected.SeriesList(), store.SeriesList())
+	assert.Equal(t, expected.Archives(), store.Archives())
+
+	// 创建 Store 后修改原始数据不影响 Store
+	data.Articles[0].Title = "changed"
+	data.Articles[0].Tags[0] = "changed"
+	data.Archives[0].Months[0].Count = 100
+	data.Series[0].ArticleIDs[0] = "changed"
+	assert.Equal(t, "A", store.Article("a").Title)
+	assert.Equal(t, []string{"T"}, store.Article("a").Tags)
+	assert.Equal(t, 1, store.ArchiveMonth(2024, 2).Count)
+	assert.Equal(t, []string{"a"}, store.Series("s").ArticleIDs)
+}
+
+func TestReplace(t *testing.T) {
+	old := storage.Current()
+	defer storage.Replace(old)
+
+	first, second := newTestStore(), storage.NewStore(&model.BlogData{}, nil)
+	storage.Replace(first)
+
+	// 读取的同时替换，每次读到的都是完整的 Store
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				store := storage.Current()
+				assert.True(t, store == first || store == second)
+				assert.Equal(t, len(store.Articles()) == 4, store.Article("go-leak") != nil)
+			}
+		}()
+	}
+	assert.Same(t, first, storage.Replace(second))
+	wg.Wait()
+	assert.Same(t, second, storage.Current())
+}
+
+// newBenchStore 生成包含 n 篇文章的 Store（5 个分类，27 个标签，每天一篇）
+func newBenchStore(n int) *storage.Store {
+	data := &model.BlogData{}
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range n {
+		day := date.AddDate(0, 0, -i)
+		data.Articles = append(data.Articles, model.Article{
+			ID:        fmt.Sprintf("article-%d", i),
+			Category:  fmt.Sprintf("category-%d", i%5),
+			Tags:      []string{fmt.Sprintf("tag-%d", i%20), fmt.Sprintf("tag-%d", 100+i%7)},
+			UpdatedAt: day.Format(time.DateOnly),
+		})
+
+		if len(data.Archives) == 0 || data.Archives[len(data.Archives)-1].Year != day.Year() {
+			data.Archives = append(data.Archives, model.ArchiveYear{Year: day.Year()})
+		}
+		year := &data.Archives[len(data.Archives)-1]
+		if len(year.Months) == 0 || year.Months[len(year.Months)-1].Month != int(day.Month()) {
+			year.Months = append(year.Months, model.ArchiveMonth{Year: day.Year(), Month: int(day.Month())})
+		}
+	}
+	return storage.NewStore(data, nil)
+}
+
+var benchSizes = []int{100, 1000, 10000}
+
+// 查询耗时与文章数量无关
+func BenchmarkStoreArticle(b *testing.B) {
+	for _, n := range benchSizes {
+		store := newBenchStore(n)
+		// 查找最后一篇，线性查找时最慢
+		id := fmt.Sprintf("article-%d", n-1)
+		b.Run(fmt.Sprintf("articles=%d", n), func(b *testing.B) {
+			for range b.N {
+				if store.Article(id) == nil {
+					b.Fatal("article not found")
+				}
+			}
+		})
+	}
+}
+
+// 按索引查找，返回结果的副本（耗时与结果中的文章数成正比，与文章总数无关）
+func BenchmarkStoreArticlesByTag(b *testing.B) {
+	for _, n := range benchSizes {
+		store := newBenchStore(n)
+		b.Run(fmt.Sprintf("articles=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if len(store.ArticlesByTag("tag-3")) == 0 {
+					b.Fatal("no articles")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkStoreArticlesByCategory(b *testing.B) {
+	for _, n := range benchSizes {
+		store := newBenchStore(n)
+		b.Run(fmt.Sprintf("articles=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if len(store.ArticlesByCategory("category-3")) == 0 {
+					b.Fatal("no articles")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkStoreArchiveMonth(b *testing.B) {
+	for _, n := range benchSizes {
+		store := newBenchStore(n)
+		b.Run(fmt.Sprintf("articles=%d", n), func(b *testing.B) {
+			for range b.N {
+				if store.ArchiveMonth(2024, 12) == nil {
+					b.Fatal("archive not found")
+				}
+			}
+		})
+	}
+}
+
+// 对比：遍历查找的耗时随文章数量线性增长
+func BenchmarkArticlesGetByID(b *testing.B) {
+	for _, n := range benchSizes {
+		articles := newBenchStore(n).Articles()
+		id := fmt.Sprintf("article-%d", n-1)
+		b.Run(fmt.Sprintf("articles=%d", n), func(b *testing.B) {
+			for range b.N {
+				if articles.GetByID(id) == nil {
+					b.Fatal("article not found")
+				}
+			}
+		})
+	}
+}
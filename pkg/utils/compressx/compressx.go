@@ -0,0 +1,88 @@
+package compressx
+
+import (
+	"compress/gzip"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	// Brotli br 编码
+	Brotli = "br"
+	// Gzip gzip 编码
+	Gzip = "gzip"
+)
+
+// Encodings 支持的压缩编码，按服务端偏好排序（压缩率更高的 br 优先）
+var Encodings = []string{Brotli, Gzip}
+
+// Extension 获取压缩编码对应的预压缩文件后缀
+func Extension(encoding string) string {
+	switch encoding {
+	case Brotli:
+		return ".br"
+	case Gzip:
+		return ".gz"
+	}
+	return ""
+}
+
+// NewWriter 创建指定编码的压缩 Writer，best 为 true 时使用最高压缩等级（适用于构建时预压缩）
+func NewWriter(encoding string, w io.Writer, best bool) io.WriteCloser {
+	switch encoding {
+	case Brotli:
+		if best {
+			return brotli.NewWriterLevel(w, brotli.BestCompression)
+		}
+		return brotli.NewWriterLevel(w, brotli.DefaultCompression)
+	case Gzip:
+		if best {
+			gw, _ := gzip.NewWriterLevel(w, gzip.BestCompression)
+			return gw
+		}
+		return gzip.NewWriter(w)
+	}
+	return nil
+}
+
+// Negotiate 根据 Accept-Encoding 头协商压缩编码，无可用编码时返回空字符串
+//
+// 参考 RFC 9110 12.5.3：q 值越大优先级越高，q=0 表示不接受，* 匹配未显式列出的编码；
+// q 值相同时按 supported 中的顺序（服务端偏好）选择
+func Negotiate(acceptEncoding string, supported []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	qualities := map[string]float64{}
+	for _, item := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(item), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+
+		quality := 1.0
+		if key, value, found := strings.Cut(strings.TrimSpace(params), "="); found && strings.TrimSpace(key) == "q" {
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				quality = q
+			}
+		}
+		qualities[coding] = quality
+	}
+
+	best, bestQuality := "", 0.0
+	for _, encoding := range supported {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality, ok = qualities["*"]
+		}
+		if ok && quality > bestQuality {
+			best, bestQuality = encoding, quality
+		}
+	}
+	return best
+}
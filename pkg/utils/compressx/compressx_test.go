@@ -0,0 +1,32 @@
+package compressx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/utils/compressx"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		acceptEncoding string
+		expected       string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", compressx.Gzip},
+		{"gzip, deflate, br", compressx.Brotli},
+		{"GZIP, BR", compressx.Brotli},
+		{"br;q=0.5, gzip;q=0.8", compressx.Gzip},
+		{"br;q=0, gzip", compressx.Gzip},
+		{"br;q=0, gzip;q=0", ""},
+		{"*", compressx.Brotli},
+		{"br;q=0, *;q=0.1", compressx.Gzip},
+		{"deflate", ""},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, compressx.Negotiate(c.acceptEncoding, compressx.Encodings), c.acceptEncoding)
+	}
+}
@@ -1,6 +1,10 @@
 package envx
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"strings"
+)
 
 // Get 读取环境变量，支持默认值
 func Get(key, fallback string) string {
@@ -9,3 +13,32 @@ func Get(key, fallback string) string {
 	}
 	return fallback
 }
+
+// GetList 读取以逗号分隔的环境变量，支持默认值，会忽略空白项
+func GetList(key, fallback string) []string {
+	var items []string
+	for _, item := range strings.Split(Get(key, fallback), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetInt 读取整数类型的环境变量，支持默认值（值不合法时同样使用默认值）
+func GetInt(key string, fallback int) int {
+	value, err := strconv.Atoi(strings.TrimSpace(Get(key, "")))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// GetBool 读取布尔类型的环境变量，支持默认值（值不合法时同样使用默认值）
+func GetBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(strings.TrimSpace(Get(key, "")))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
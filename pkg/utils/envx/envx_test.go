@@ -17,3 +17,43 @@ func TestGetEnvWithDefault(t *testing.T) {
 	ret = envx.Get("PATH", "")
 	assert.NotEqual(t, "", ret)
 }
+
+func TestGetListEnvWithDefault(t *testing.T) {
+	// 不存在的环境变量
+	ret := envx.GetList("NOT_EXISTS_ENV_KEY", "a, b,,c ")
+	assert.Equal(t, []string{"a", "b", "c"}, ret)
+
+	// 已存在的环境变量
+	t.Setenv("EXISTS_LIST_ENV_KEY", "10.0.0.0/8")
+	ret = envx.GetList("EXISTS_LIST_ENV_KEY", "")
+	assert.Equal(t, []string{"10.0.0.0/8"}, ret)
+
+	// 空值
+	assert.Empty(t, envx.GetList("NOT_EXISTS_ENV_KEY", ""))
+}
+
+func TestGetIntEnvWithDefault(t *testing.T) {
+	// 不存在的环境变量
+	assert.Equal(t, 10, envx.GetInt("NOT_EXISTS_ENV_KEY", 10))
+
+	// 已存在的环境变量
+	t.Setenv("EXISTS_INT_ENV_KEY", "30")
+	assert.Equal(t, 30, envx.GetInt("EXISTS_INT_ENV_KEY", 10))
+
+	// 不合法的值
+	t.Setenv("INVALID_INT_ENV_KEY", "abc")
+	assert.Equal(t, 10, envx.GetInt("INVALID_INT_ENV_KEY", 10))
+}
+
+func TestGetBoolEnvWithDefault(t *testing.T) {
+	// 不存在的环境变量
+	assert.True(t, envx.GetBool("NOT_EXISTS_ENV_KEY", true))
+
+	// 已存在的环境变量
+	t.Setenv("EXISTS_BOOL_ENV_KEY", "false")
+	assert.False(t, envx.GetBool("EXISTS_BOOL_ENV_KEY", true))
+
+	// 不合法的值
+	t.Setenv("INVALID_BOOL_ENV_KEY", "yes")
+	assert.True(t, envx.GetBool("INVALID_BOOL_ENV_KEY", true))
+}
@@ -0,0 +1,75 @@
+package ginx
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CheckNotModified 设置缓存校验头（ETag / Last-Modified），并根据条件请求头判断客户端缓存是否仍然有效，
+// 有效则直接响应 304 并返回 true，调用方无需再渲染响应内容；etag 为空 / lastModified 为零值表示不设置对应的头
+func CheckNotModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if etag != "" {
+		etag = `"` + etag + `"`
+		c.Header("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+	if !isNotModified(c.Request, etag, lastModified) {
+		return false
+	}
+
+	c.Status(http.StatusNotModified)
+	c.Abort()
+	return true
+}
+
+// isNotModified 参考 RFC 9110 13.2.2，If-None-Match 存在时忽略 If-Modified-Since
+func isNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etag != "" && etagMatch(inm, etag)
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	t, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// HTTP 日期精度为秒
+	return !lastModified.Truncate(time.Second).After(t)
+}
+
+// etagMatch 弱比较（忽略 W/ 前缀），If-None-Match 可能包含多个 ETag 或 *
+func etagMatch(inm, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(inm, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddVary 向响应的 Vary 头中添加字段（已存在时不重复添加）
+func AddVary(c *gin.Context, field string) {
+	header := c.Writer.Header()
+	for _, value := range header.Values("Vary") {
+		for _, existing := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(existing), field) {
+				return
+			}
+		}
+	}
+	header.Add("Vary", field)
+}
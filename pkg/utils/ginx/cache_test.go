@@ -0,0 +1,116 @@
+package ginx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/utils/ginx"
+)
+
+func TestCheckNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lastModified := time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		method   string
+		headers  map[string]string
+		expected int
+	}{
+		{
+			name:     "no conditional headers",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "etag matched",
+			headers:  map[string]string{"If-None-Match": `"abc"`},
+			expected: http.StatusNotModified,
+		},
+		{
+			name:     "weak etag in list matched",
+			headers:  map[string]string{"If-None-Match": `"xyz", W/"abc"`},
+			expected: http.StatusNotModified,
+		},
+		{
+			name:     "wildcard etag",
+			headers:  map[string]string{"If-None-Match": "*"},
+			expected: http.StatusNotModified,
+		},
+		{
+			name:     "etag mismatched",
+			headers:  map[string]string{"If-None-Match": `"xyz"`},
+			expected: http.StatusOK,
+		},
+		{
+			name: "if-none-match takes precedence over if-modified-since",
+			headers: map[string]string{
+				"If-None-Match":     `"xyz"`,
+				"If-Modified-Since": lastModified.Add(time.Hour).Format(http.TimeFormat),
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "not modified since",
+			headers:  map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)},
+			expected: http.StatusNotModified,
+		},
+		{
+			name:     "modified since",
+			headers:  map[string]string{"If-Modified-Since": lastModified.Add(-time.Hour).Format(http.TimeFormat)},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "invalid if-modified-since",
+			headers:  map[string]string{"If-Modified-Since": "yesterday"},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "unsafe method is never not modified",
+			method:   http.MethodPost,
+			headers:  map[string]string{"If-None-Match": `"abc"`},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router := gin.New()
+			router.Any("/", func(c *gin.Context) {
+				if ginx.CheckNotModified(c, "abc", lastModified) {
+					return
+				}
+				c.String(http.StatusOK, "content")
+			})
+
+			req := httptest.NewRequest(c.method, "/", nil)
+			for key, value := range c.headers {
+				req.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, c.expected, w.Code)
+			assert.Equal(t, `"abc"`, w.Header().Get("ETag"))
+			assert.Equal(t, "Wed, 12 Nov 2025 00:00:00 GMT", w.Header().Get("Last-Modified"))
+			if c.expected == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAddVary(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Writer.Header().Set("Vary", "Origin, accept-encoding")
+
+	ginx.AddVary(c, "Accept-Encoding")
+	ginx.AddVary(c, "Cookie")
+	ginx.AddVary(c, "Cookie")
+	assert.Equal(t, []string{"Origin, accept-encoding", "Cookie"}, c.Writer.Header().Values("Vary"))
+}
@@ -0,0 +1,147 @@
+package ginx
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	// ForwardedHeaderKey RFC 7239 标准转发头
+	ForwardedHeaderKey = "Forwarded"
+	// XForwardedForHeaderKey 事实标准的转发头
+	XForwardedForHeaderKey = "X-Forwarded-For"
+)
+
+// IPResolver 客户端真实 IP 解析器
+//
+// 仅当 TCP 连接的对端为可信代理时，才会读取转发头，否则任何人都可以通过伪造请求头的方式冒充其他 IP；
+// 只读取可信代理写入的那一个转发头，其他转发头都可能是客户端伪造后被代理原样透传的，不能作为备选；
+// 转发链路自右向左解析，遇到的第一个非可信代理地址即为客户端 IP
+type IPResolver struct {
+	trustedProxies []*net.IPNet
+	headerKey      string
+}
+
+// NewIPResolver 创建 IP 解析器，trustedProxies 支持 IP 或 CIDR，
+// headerKey 为可信代理写入的转发头（如 X-Forwarded-For / Forwarded / X-Real-IP），为空则使用 X-Forwarded-For
+func NewIPResolver(trustedProxies []string, headerKey string) (*IPResolver, error) {
+	resolver := &IPResolver{headerKey: lo.Ternary(headerKey != "", headerKey, XForwardedForHeaderKey)}
+	for _, proxy := range trustedProxies {
+		if strings.Contains(proxy, "/") {
+			_, ipNet, err := net.ParseCIDR(proxy)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid trusted proxy cidr %s", proxy)
+			}
+			resolver.trustedProxies = append(resolver.trustedProxies, ipNet)
+			continue
+		}
+
+		ip := net.ParseIP(proxy)
+		if ip == nil {
+			return nil, errors.Errorf("invalid trusted proxy ip %s", proxy)
+		}
+		bits := lo.Ternary(ip.To4() != nil, 32, 128)
+		resolver.trustedProxies = append(resolver.trustedProxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return resolver, nil
+}
+
+// Resolve 解析请求的真实客户端 IP
+func (r *IPResolver) Resolve(req *http.Request) string {
+	remoteIP := parseIP(req.RemoteAddr)
+	if remoteIP == nil {
+		// 非 TCP 连接（如 Unix Socket）或测试场景，原样返回
+		return req.RemoteAddr
+	}
+	// 对端不可信，转发头都有可能是伪造的
+	if !r.isTrusted(remoteIP) {
+		return remoteIP.String()
+	}
+
+	// 自右向左遍历转发链路，跳过可信代理
+	clientIP, hops := remoteIP, r.forwardedHops(req.Header)
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := parseIP(hops[i])
+		// 非法值（如 unknown / 混淆标识）无法继续追溯，以最后一个合法的地址为准
+		if ip == nil {
+			break
+		}
+		clientIP = ip
+		if !r.isTrusted(ip) {
+			break
+		}
+	}
+	return clientIP.String()
+}
+
+func (r *IPResolver) isTrusted(ip net.IP) bool {
+	for _, ipNet := range r.trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedHops 获取转发头中的地址列表（从左到右为 客户端 -> 最近的代理），
+// RFC 7239 Forwarded 头取各元素的 for 参数，其他头（X-Forwarded-For / X-Real-IP 等）按逗号切分
+func (r *IPResolver) forwardedHops(header http.Header) []string {
+	var hops []string
+	if strings.EqualFold(r.headerKey, ForwardedHeaderKey) {
+		for _, value := range header.Values(ForwardedHeaderKey) {
+			for _, element := range splitQuoted(value, ',') {
+				hops = append(hops, forwardedFor(element))
+			}
+		}
+		return hops
+	}
+
+	for _, value := range header.Values(r.headerKey) {
+		for _, hop := range strings.Split(value, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	return hops
+}
+
+// forwardedFor 获取 Forwarded 元素中的 for 参数，如 for="[2001:db8::1]:4711";proto=https
+func forwardedFor(element string) string {
+	for _, pair := range splitQuoted(element, ';') {
+		key, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if found && strings.EqualFold(key, "for") {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// splitQuoted 按分隔符切分字符串，忽略双引号内的分隔符
+func splitQuoted(s string, sep rune) []string {
+	var parts []string
+	quoted, start := false, 0
+	for idx, ch := range s {
+		switch ch {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				parts = append(parts, s[start:idx])
+				start = idx + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseIP 解析 IP，支持 1.2.3.4 / 1.2.3.4:80 / 2001:db8::1 / [2001:db8::1]:80 等格式
+func parseIP(s string) net.IP {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	return net.ParseIP(strings.Trim(s, "[]"))
+}
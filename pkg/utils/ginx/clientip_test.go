@@ -0,0 +1,209 @@
+package ginx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/utils/ginx"
+)
+
+func TestNewIPResolver(t *testing.T) {
+	_, err := ginx.NewIPResolver([]string{"10.0.0.0/8", "127.0.0.1", "::1"}, "")
+	assert.Nil(t, err)
+
+	_, err = ginx.NewIPResolver([]string{"10.0.0.0/33"}, "")
+	assert.NotNil(t, err)
+
+	_, err = ginx.NewIPResolver([]string{"localhost"}, "")
+	assert.NotNil(t, err)
+}
+
+func TestIPResolverResolve(t *testing.T) {
+	trustedProxies := []string{"10.0.0.0/8", "2001:db8:ffff::/48"}
+
+	cases := []struct {
+		name       string
+		headerKey  string
+		remoteAddr string
+		headers    map[string][]string
+		expected   string
+	}{
+		{
+			name:       "direct connection",
+			remoteAddr: "203.0.113.7:52100",
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "spoof x-real-ip from untrusted peer",
+			headerKey:  "X-Real-IP",
+			remoteAddr: "203.0.113.7:52100",
+			headers:    map[string][]string{"X-Real-IP": {"1.1.1.1"}},
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "spoof x-forwarded-for from untrusted peer",
+			remoteAddr: "203.0.113.7:52100",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1, 10.0.0.2"}},
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "spoof forwarded from untrusted peer",
+			remoteAddr: "203.0.113.7:52100",
+			headers:    map[string][]string{"Forwarded": {"for=1.1.1.1"}},
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "x-real-ip from trusted proxy",
+			headerKey:  "X-Real-IP",
+			remoteAddr: "10.0.0.2:40000",
+			headers:    map[string][]string{"X-Real-IP": {"198.51.100.9"}},
+			expected:   "198.51.100.9",
+		},
+		{
+			name:       "invalid x-real-ip never falls back to other headers",
+			headerKey:  "X-Real-IP",
+			remoteAddr: "10.0.0.2:40000",
+			headers: map[string][]string{
+				"X-Real-IP":       {"not-an-ip"},
+				"X-Forwarded-For": {"198.51.100.9"},
+				"Forwarded":       {"for=198.51.100.9"},
+			},
+			expected: "10.0.0.2",
+		},
+		{
+			name:       "x-forwarded-for ignored when proxy sets x-real-ip",
+			headerKey:  "X-Real-IP",
+			remoteAddr: "10.0.0.2:40000",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1"}},
+			expected:   "10.0.0.2",
+		},
+		{
+			name:       "x-forwarded-for prepended by client is ignored",
+			remoteAddr: "10.0.0.2:40000",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1, 198.51.100.9"}},
+			expected:   "198.51.100.9",
+		},
+		{
+			name:       "x-forwarded-for skips multiple trusted proxies",
+			remoteAddr: "10.0.0.3:40000",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1, 198.51.100.9, 10.0.0.2"}},
+			expected:   "198.51.100.9",
+		},
+		{
+			name:       "multiple x-forwarded-for headers",
+			remoteAddr: "10.0.0.3:40000",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1", "198.51.100.9, 10.0.0.2"}},
+			expected:   "198.51.100.9",
+		},
+		{
+			name:       "all hops trusted returns leftmost",
+			remoteAddr: "10.0.0.3:40000",
+			headers:    map[string][]string{"X-Forwarded-For": {"10.0.0.1, 10.0.0.2"}},
+			expected:   "10.0.0.1",
+		},
+		{
+			name:       "garbage hop stops the walk",
+			remoteAddr: "10.0.0.3:40000",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1, unknown, 10.0.0.2"}},
+			expected:   "10.0.0.2",
+		},
+		{
+			name:       "trusted proxy without forwarding headers",
+			remoteAddr: "10.0.0.3:40000",
+			expected:   "10.0.0.3",
+		},
+		{
+			name:       "client forwarded header ignored by default",
+			remoteAddr: "10.0.0.3:40000",
+			headers: map[string][]string{
+				"Forwarded":       {"for=1.1.1.1"},
+				"X-Forwarded-For": {"198.51.100.9"},
+			},
+			expected: "198.51.100.9",
+		},
+		{
+			name:       "client forwarded header without x-forwarded-for",
+			remoteAddr: "10.0.0.3:40000",
+			headers:    map[string][]string{"Forwarded": {"for=1.1.1.1"}},
+			expected:   "10.0.0.3",
+		},
+		{
+			name:       "forwarded header with ipv6 and port",
+			headerKey:  "Forwarded",
+			remoteAddr: "10.0.0.3:40000",
+			headers: map[string][]string{
+				"Forwarded": {`for=1.1.1.1, for="[2001:db8:cafe::17]:4711";proto=https;by=10.0.0.3`},
+			},
+			expected: "2001:db8:cafe::17",
+		},
+		{
+			name:       "client x-forwarded-for ignored when proxy sets forwarded",
+			headerKey:  "forwarded",
+			remoteAddr: "10.0.0.3:40000",
+			headers: map[string][]string{
+				"Forwarded":       {"for=198.51.100.9;proto=https"},
+				"X-Forwarded-For": {"1.1.1.1"},
+			},
+			expected: "198.51.100.9",
+		},
+		{
+			name:       "forwarded obfuscated identifier stops the walk",
+			headerKey:  "Forwarded",
+			remoteAddr: "10.0.0.3:40000",
+			headers:    map[string][]string{"Forwarded": {"for=1.1.1.1, for=_hidden, for=10.0.0.2"}},
+			expected:   "10.0.0.2",
+		},
+		{
+			name:       "forwarded quoted value with separators",
+			headerKey:  "Forwarded",
+			remoteAddr: "10.0.0.3:40000",
+			headers:    map[string][]string{"Forwarded": {`for=198.51.100.9;by="a,b;c"`}},
+			expected:   "198.51.100.9",
+		},
+		{
+			name:       "ipv6 trusted proxy",
+			remoteAddr: "[2001:db8:ffff::1]:443",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.9"}},
+			expected:   "198.51.100.9",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resolver, err := ginx.NewIPResolver(trustedProxies, c.headerKey)
+			assert.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = c.remoteAddr
+			for key, values := range c.headers {
+				for _, value := range values {
+					req.Header.Add(key, value)
+				}
+			}
+			assert.Equal(t, c.expected, resolver.Resolve(req))
+		})
+	}
+}
+
+func TestGetClientIP(t *testing.T) {
+	newContext := func() *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Request.RemoteAddr = "127.0.0.1:12345"
+		c.Request.Header.Set("X-Real-IP", "1.2.3.4")
+		return c
+	}
+
+	// 配置有误时返回错误，不影响已有的解析器
+	assert.NotNil(t, ginx.InitIPResolver([]string{"localhost"}, "X-Real-IP"))
+
+	assert.Nil(t, ginx.InitIPResolver([]string{"127.0.0.0/8"}, "X-Real-IP"))
+	assert.Equal(t, "1.2.3.4", ginx.GetClientIP(newContext()))
+
+	assert.Nil(t, ginx.InitIPResolver(nil, "X-Real-IP"))
+	assert.Equal(t, "127.0.0.1", ginx.GetClientIP(newContext()))
+}
@@ -1,9 +1,9 @@
 package ginx
 
 import (
-	"github.com/gin-gonic/gin"
+	"sync/atomic"
 
-	"github.com/narasux/goblog/pkg/envs"
+	"github.com/gin-gonic/gin"
 )
 
 const (
@@ -45,10 +45,27 @@ func SetError(c *gin.Context, err error) {
 	c.Set(ErrorKey, err)
 }
 
-// GetClientIP ...
+var (
+	// 客户端 IP 解析器，服务启动时通过 InitIPResolver 初始化
+	ipResolver atomic.Pointer[IPResolver]
+	// 未初始化时使用的解析器（不信任任何代理）
+	untrustedIPResolver = &IPResolver{headerKey: XForwardedForHeaderKey}
+)
+
+// InitIPResolver 根据可信代理配置初始化客户端 IP 解析器，配置有误时返回错误，应在服务启动时调用
+func InitIPResolver(trustedProxies []string, headerKey string) error {
+	resolver, err := NewIPResolver(trustedProxies, headerKey)
+	if err != nil {
+		return err
+	}
+	ipResolver.Store(resolver)
+	return nil
+}
+
+// GetClientIP 获取真实客户端 IP（未初始化解析器时不读取任何转发头，直接使用 TCP 连接的对端地址）
 func GetClientIP(c *gin.Context) string {
-	if envs.RealClientIPHeaderKey != "" {
-		return c.GetHeader(envs.RealClientIPHeaderKey)
+	if resolver := ipResolver.Load(); resolver != nil {
+		return resolver.Resolve(c.Request)
 	}
-	return c.ClientIP()
+	return untrustedIPResolver.Resolve(c.Request)
 }
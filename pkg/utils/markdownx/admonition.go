@@ -0,0 +1,178 @@
+package markdownx
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+	"github.com/gomarkdown/markdown/html"
+)
+
+// AdmonitionKind 提示块类型
+type AdmonitionKind string
+
+const (
+	// AdmonitionNote 说明
+	AdmonitionNote AdmonitionKind = "note"
+	// AdmonitionTip 提示
+	AdmonitionTip AdmonitionKind = "tip"
+	// AdmonitionWarning 警告
+	AdmonitionWarning AdmonitionKind = "warning"
+)
+
+// AdmonitionKinds 支持的提示块类型
+var AdmonitionKinds = []AdmonitionKind{AdmonitionNote, AdmonitionTip, AdmonitionWarning}
+
+// 提示块的默认标题
+var admonitionTitles = map[AdmonitionKind]string{
+	AdmonitionNote:    "说明",
+	AdmonitionTip:     "提示",
+	AdmonitionWarning: "警告",
+}
+
+// ClassMapKey 提示块容器在类映射表中的键，如 admonition-note
+func (k AdmonitionKind) ClassMapKey() string {
+	return "admonition-" + string(k)
+}
+
+// Admonition 提示块，由首行为 [!NOTE] / [!TIP] / [!WARNING] 的引用块转换而来（与 GitHub 语法一致）
+type Admonition struct {
+	ast.Container
+
+	Kind AdmonitionKind
+}
+
+// parseAdmonitions 将文档中的提示块引用转换为 Admonition 节点（首行的类型标记会被移除）
+//
+// 解析器会将空行分隔的相邻引用块合并为一个，因此引用块中每个以类型标记开头的段落都会开始一个新的提示块
+func parseAdmonitions(doc ast.Node) {
+	// 遍历过程中不能替换节点，因此先收集（先序，嵌套的引用块在外层之后转换）
+	var blockQuotes []*ast.BlockQuote
+	ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+		if blockQuote, ok := node.(*ast.BlockQuote); ok && entering {
+			blockQuotes = append(blockQuotes, blockQuote)
+		}
+		return ast.GoToNext
+	})
+
+	for _, blockQuote := range blockQuotes {
+		if !slices.ContainsFunc(blockQuote.Children, isAdmonitionStart) {
+			continue
+		}
+		var nodes []ast.Node
+		var current ast.Node
+		for _, child := range slices.Clone(blockQuote.Children) {
+			if isAdmonitionStart(child) {
+				current = &Admonition{Kind: cutAdmonitionMarker(child.(*ast.Paragraph))}
+				nodes = append(nodes, current)
+				// 只有类型标记的段落不再保留
+				if isEmptyParagraph(child.(*ast.Paragraph)) {
+					continue
+				}
+			} else if current == nil {
+				// 第一个类型标记之前的内容仍属于普通引用块
+				current = &ast.BlockQuote{Container: ast.Container{Attribute: blockQuote.Attribute}}
+				nodes = append(nodes, current)
+			}
+			child.SetParent(current)
+			current.SetChildren(append(current.GetChildren(), child))
+		}
+		replaceNode(blockQuote, nodes)
+	}
+}
+
+// 段落首行的提示块类型标记（如 [!NOTE]），不是标记则返回空
+func admonitionMarker(node ast.Node) (marker []byte, kind AdmonitionKind) {
+	paragraph, ok := node.(*ast.Paragraph)
+	if !ok {
+		return nil, ""
+	}
+	text, ok := ast.GetFirstChild(paragraph).(*ast.Text)
+	if !ok {
+		return nil, ""
+	}
+	line, _, _ := bytes.Cut(text.Literal, []byte("\n"))
+	trimmed := bytes.TrimSpace(line)
+	if !bytes.HasPrefix(trimmed, []byte("[!")) || !bytes.HasSuffix(trimmed, []byte("]")) {
+		return nil, ""
+	}
+	kind = AdmonitionKind(strings.ToLower(string(trimmed[2 : len(trimmed)-1])))
+	if _, ok = admonitionTitles[kind]; !ok {
+		return nil, ""
+	}
+	return line, kind
+}
+
+// isAdmonitionStart 节点是否为以提示块类型标记开头的段落
+func isAdmonitionStart(node ast.Node) bool {
+	_, kind := admonitionMarker(node)
+	return kind != ""
+}
+
+// cutAdmonitionMarker 移除段落首行的类型标记，返回提示块类型
+func cutAdmonitionMarker(paragraph *ast.Paragraph) AdmonitionKind {
+	marker, kind := admonitionMarker(paragraph)
+	text := ast.GetFirstChild(paragraph).(*ast.Text)
+	text.Literal = bytes.TrimPrefix(bytes.TrimPrefix(text.Literal, marker), []byte("\n"))
+	return kind
+}
+
+// isEmptyParagraph 段落只包含空白文本
+func isEmptyParagraph(paragraph *ast.Paragraph) bool {
+	for _, child := range paragraph.Children {
+		if text, ok := child.(*ast.Text); !ok || len(bytes.TrimSpace(text.Literal)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceNode 将节点替换为多个节点（保持在父节点中的位置）
+func replaceNode(node ast.Node, replacements []ast.Node) {
+	parent := node.GetParent()
+	var children []ast.Node
+	for _, child := range parent.GetChildren() {
+		if child != node {
+			children = append(children, child)
+			continue
+		}
+		for _, replacement := range replacements {
+			replacement.SetParent(parent)
+			children = append(children, replacement)
+		}
+	}
+	parent.SetChildren(children)
+}
+
+// renderAdmonition 输出提示块：标题（图标 + 类型名称）及正文，类映射表中没有对应类型时使用引用块的样式
+func (h *nodeHook) renderAdmonition(w io.Writer, admonition *Admonition, entering bool) {
+	if !entering {
+		// 默认渲染器只在引用块（而非提示块）中的列表之后换行
+		if _, ok := ast.GetLastChild(admonition).(*ast.List); ok {
+			h.renderer.CR(w)
+		}
+		h.renderer.Outs(w, "</div>")
+		h.renderer.CR(w)
+		return
+	}
+
+	class := h.classMap[admonition.Kind.ClassMapKey()]
+	if class == "" {
+		class = h.classMap["blockquote"]
+	}
+	attrs := []string{`role="note"`}
+	if class != "" {
+		attrs = append([]string{classAttr(class)}, attrs...)
+	}
+	h.renderer.CR(w)
+	h.renderer.OutTag(w, "<div", attrs)
+	h.renderer.CR(w)
+	h.renderer.OutTag(w, "<p", h.classAttrs(ClassMapKeyAdmonitionTitle))
+	h.renderer.Outs(w, h.admonitionIcons[admonition.Kind])
+	h.renderer.Outs(w, "<span>")
+	html.EscapeHTML(w, []byte(admonitionTitles[admonition.Kind]))
+	h.renderer.Outs(w, "</span></p>")
+	h.renderer.CR(w)
+}
@@ -0,0 +1,76 @@
+package markdownx
+
+// ClassMap html 标签 -> css 类的映射表，除标签名外，还支持以下特殊的键：
+// - code-block：带语言标识的代码块中的 code 标签（会追加在 language-xxx 之后）
+// - code-title：代码块标题（文件名）
+// - heading-anchor：标题后的锚点链接
+// - admonition-note / admonition-tip / admonition-warning：各类提示块（缺省时使用 blockquote 的样式）
+// - admonition-title：提示块标题
+// - footnotes：文末的脚注区域
+// - footnote-ref / footnote-backref：正文中的脚注引用 / 脚注中返回正文的链接
+type ClassMap map[string]string
+
+// HeadingAnchorClass 标题锚点固定的 css 类（无论类映射表如何配置，页面脚本都依赖该类）
+const HeadingAnchorClass = "heading-anchor"
+
+const (
+	// ClassMapKeyCodeBlock 带语言标识的代码块
+	ClassMapKeyCodeBlock = "code-block"
+	// ClassMapKeyCodeTitle 代码块标题
+	ClassMapKeyCodeTitle = "code-title"
+	// ClassMapKeyHeadingAnchor 标题锚点
+	ClassMapKeyHeadingAnchor = "heading-anchor"
+	// ClassMapKeyAdmonitionTitle 提示块标题
+	ClassMapKeyAdmonitionTitle = "admonition-title"
+	// ClassMapKeyFootnotes 脚注区域
+	ClassMapKeyFootnotes = "footnotes"
+	// ClassMapKeyFootnoteRef 脚注引用
+	ClassMapKeyFootnoteRef = "footnote-ref"
+	// ClassMapKeyFootnoteBackRef 脚注返回链接
+	ClassMapKeyFootnoteBackRef = "footnote-backref"
+)
+
+// DefaultClassMap 默认的 tailwind css 类映射表
+var DefaultClassMap = ClassMap{
+	"p":   "my-2 mx-2",
+	"ol":  "pl-1 list-decimal list-inside",
+	"ul":  "pl-4 list-disc",
+	"li":  "ml-4 my-2",
+	"pre": "my-4",
+	// markdown 单行 code 效果（带语言标识的代码块另外处理）
+	"code": "bg-gray-100 text-orange-600",
+	// 由于代码块的 code 标签本身自带 class="language-xxx"，因此不能直接替换，只能补充
+	ClassMapKeyCodeBlock: "block overflow-x-auto p-4 rounded-xl",
+	ClassMapKeyCodeTitle: "inline-block px-4 py-1 rounded-t-lg bg-gray-700 font-mono text-sm text-gray-100",
+	// 使用 left-padding + left-border + bg-color 实现 markdown 引用的效果 :D
+	"blockquote": "mt-2 pl-2 py-1 border-l-8 border-green-200 bg-green-100",
+	// 提示块：与引用类似，按类型区分颜色
+	"admonition-note":          "my-4 pl-2 py-1 rounded-r-lg border-l-8 border-sky-300 bg-sky-100",
+	"admonition-tip":           "my-4 pl-2 py-1 rounded-r-lg border-l-8 border-emerald-300 bg-emerald-100",
+	"admonition-warning":       "my-4 pl-2 py-1 rounded-r-lg border-l-8 border-amber-300 bg-amber-100",
+	ClassMapKeyAdmonitionTitle: "flex items-center gap-2 mx-2 my-2 font-bold",
+	// 斑马表格：奇偶数行不同背景色
+	"table": "table-auto border-collapse border border-gray-500",
+	"tr":    "odd:bg-white even:bg-gray-100",
+	"th":    "border border-gray-500 px-4 py-2",
+	"td":    "border border-gray-500 px-4 py-2",
+	// group 用于悬停标题时展示锚点
+	"h1":  "group mt-6 mb-4 font-semibold text-3xl",
+	"h2":  "group mt-6 mb-4 font-semibold text-2xl",
+	"h3":  "group mt-6 mb-4 font-semibold text-xl",
+	"h4":  "group mt-6 mb-4 font-semibold text-lg",
+	"h5":  "group mt-6 mb-4 font-semibold text-base",
+	"h6":  "group mt-6 mb-4 font-semibold text-base text-gray-600",
+	"img": "my-6",
+	"a":   "text-blue-500",
+	// 带标题的图片
+	"figure":     "my-6 flex flex-col items-center",
+	"figcaption": "-mt-4 text-sm text-gray-500",
+	// 脚注
+	ClassMapKeyFootnotes:       "mt-8 text-sm text-gray-600",
+	ClassMapKeyFootnoteRef:     "px-0.5 text-blue-500",
+	ClassMapKeyFootnoteBackRef: "ml-1 text-blue-500",
+
+	// 标题锚点默认隐藏，悬停标题时展示
+	ClassMapKeyHeadingAnchor: "ml-2 text-gray-400 opacity-0 group-hover:opacity-100 hover:text-sky-600",
+}
@@ -0,0 +1,81 @@
+package markdownx
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/gomarkdown/markdown/ast"
+	"github.com/gomarkdown/markdown/html"
+)
+
+// 脚注引用及脚注的锚点前缀，完整锚点如 #fnref:1，#fn:1（同一脚注被多次引用时为 #fnref:1:2）
+const (
+	footnoteRefPrefix = "fnref:"
+	footnotePrefix    = "fn:"
+)
+
+// renderFootnoteRef 输出正文中的脚注引用（上标序号），同一脚注的每次引用使用不同的锚点，以便脚注返回到对应位置
+func (h *nodeHook) renderFootnoteRef(w io.Writer, link *ast.Link) {
+	slug := string(html.Slugify(link.Destination))
+	h.footnoteRefs[slug]++
+
+	h.renderer.Outs(w, `<sup id="`+footnoteRefID(slug, h.footnoteRefs[slug])+`">`)
+	attrs := append(h.classAttrs(ClassMapKeyFootnoteRef), `href="#`+footnotePrefix+slug+`"`, `role="doc-noteref"`)
+	h.renderer.OutTag(w, "<a", attrs)
+	h.renderer.Outs(w, strconv.Itoa(link.NoteID)+"</a></sup>")
+}
+
+// renderFootnotes 输出文末的脚注列表
+func (h *nodeHook) renderFootnotes(w io.Writer, list *ast.List, entering bool) {
+	if !entering {
+		h.renderer.Outs(w, "</ol>")
+		h.renderer.CR(w)
+		h.renderer.Outs(w, "</section>")
+		h.renderer.CR(w)
+		return
+	}
+	h.renderer.CR(w)
+	h.renderer.OutTag(w, "<section", append(h.classAttrs(ClassMapKeyFootnotes), `role="doc-endnotes"`))
+	h.renderer.CR(w)
+	h.renderer.OutHRTag(w, nil)
+	h.renderer.CR(w)
+	h.renderer.OutTag(w, "<ol", h.classAttrs("ol"))
+	h.renderer.CR(w)
+}
+
+// renderFootnote 输出脚注，末尾为返回正文的链接（被多次引用时，每次引用都有一个返回链接）
+func (h *nodeHook) renderFootnote(w io.Writer, listItem *ast.ListItem, entering bool) {
+	slug := string(html.Slugify(listItem.RefLink))
+	if entering {
+		if html.ListItemOpenCR(listItem) {
+			h.renderer.CR(w)
+		}
+		h.renderer.OutTag(w, "<li", append(h.classAttrs("li"), `id="`+footnotePrefix+slug+`"`))
+		return
+	}
+
+	for n := 1; n <= h.footnoteRefs[slug]; n++ {
+		content := "↩"
+		if n > 1 {
+			content += fmt.Sprintf("<sup>%d</sup>", n)
+		}
+		attrs := append(
+			h.classAttrs(ClassMapKeyFootnoteBackRef),
+			`href="#`+footnoteRefID(slug, n)+`"`, `role="doc-backlink"`, `aria-label="返回正文"`,
+		)
+		h.renderer.Outs(w, " ")
+		h.renderer.OutTag(w, "<a", attrs)
+		h.renderer.Outs(w, content+"</a>")
+	}
+	h.renderer.Outs(w, "</li>")
+	h.renderer.CR(w)
+}
+
+// footnoteRefID 脚注第 n 次被引用处的锚点 ID
+func footnoteRefID(slug string, n int) string {
+	if n == 1 {
+		return footnoteRefPrefix + slug
+	}
+	return fmt.Sprintf("%s%s:%d", footnoteRefPrefix, slug, n)
+}
@@ -0,0 +1,169 @@
+package markdownx
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/gomarkdown/markdown/html"
+	"github.com/pkg/errors"
+)
+
+// HighlightClass 代码高亮的 css 类（与 chroma 生成的主题 css 一致）
+const HighlightClass = "chroma"
+
+// 代码块的语言标识（如 go，c++，c#，objective-c），会用于 css 类名，不合法的语言标识会被忽略
+var fenceLangRegex = regexp.MustCompile(`^[\w+#.-]+$`)
+
+// fenceInfo 代码块的信息（``` 之后的部分），格式为：语言 [{高亮行}] [linenos] [title="文件名"]，如：
+//
+//	```go {2,5-7} linenos title="main.go"
+type fenceInfo struct {
+	lang string
+	// 高亮的行（闭区间，从 1 开始）
+	highlightLines [][2]int
+	// 是否展示行号
+	lineNumbers bool
+	// 代码块标题（通常为文件名）
+	title string
+}
+
+// parseFenceInfo 解析代码块信息，不合法的部分会被忽略
+func parseFenceInfo(info string) fenceInfo {
+	var fi fenceInfo
+	for idx, field := range splitFenceInfo(info) {
+		switch {
+		case strings.HasPrefix(field, "{") && strings.HasSuffix(field, "}"):
+			fi.highlightLines = parseLineRanges(field[1 : len(field)-1])
+		case field == "linenos":
+			fi.lineNumbers = true
+		case strings.Contains(field, "="):
+			key, value, _ := strings.Cut(field, "=")
+			switch strings.ToLower(key) {
+			case "title", "filename":
+				fi.title = strings.Trim(value, `"'`)
+			case "linenos":
+				fi.lineNumbers, _ = strconv.ParseBool(value)
+			}
+		case idx == 0 && fenceLangRegex.MatchString(field):
+			fi.lang = field
+		}
+	}
+	return fi
+}
+
+// splitFenceInfo 按空白字符切分代码块信息，忽略引号内的空白字符
+func splitFenceInfo(info string) []string {
+	var fields []string
+	var field strings.Builder
+	var quote rune
+	for _, ch := range info {
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == ' ' || ch == '\t':
+			if field.Len() != 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+			continue
+		}
+		field.WriteRune(ch)
+	}
+	if field.Len() != 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// parseLineRanges 解析行号范围，如 2,5-7 -> [[2,2], [5,7]]
+func parseLineRanges(s string) [][2]int {
+	var ranges [][2]int
+	for _, part := range strings.Split(s, ",") {
+		startStr, endStr, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		start, err := strconv.Atoi(startStr)
+		if err != nil || start <= 0 {
+			continue
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(endStr); err != nil || end < start {
+				continue
+			}
+		}
+		ranges = append(ranges, [2]int{start, end})
+	}
+	return ranges
+}
+
+// codePreWrapper 自定义代码块外层标签，使其与未高亮的代码块结构一致（pre > code.language-xxx）
+type codePreWrapper struct {
+	preAttrs  []string
+	codeClass string
+}
+
+// Start ...
+func (p codePreWrapper) Start(code bool, _ string) string {
+	pre := html.TagWithAttributes("<pre", p.preAttrs)
+	if !code {
+		return pre
+	}
+	return pre + `<code ` + classAttr(p.codeClass) + `>`
+}
+
+// End ...
+func (p codePreWrapper) End(code bool) string {
+	if !code {
+		return "</pre>"
+	}
+	return "</code></pre>"
+}
+
+// highlight 使用 chroma 高亮代码（输出 css 类而非内联样式，主题 css 由 goblog assets highlight-css 生成）
+func (h *nodeHook) highlight(w io.Writer, code string, fi fenceInfo) error {
+	lexer := lexers.Get(fi.lang)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return err
+	}
+
+	lang := fi.lang
+	if lang == "" {
+		lang = "plaintext"
+	}
+	formatter := chromahtml.New(
+		chromahtml.WithClasses(true),
+		chromahtml.WithLineNumbers(fi.lineNumbers),
+		chromahtml.HighlightLines(fi.highlightLines),
+		chromahtml.WithPreWrapper(codePreWrapper{
+			preAttrs: h.classAttrs("pre"),
+			codeClass: strings.TrimSpace(
+				fmt.Sprintf("language-%s %s %s", lang, HighlightClass, h.classMap[ClassMapKeyCodeBlock]),
+			),
+		}),
+	)
+	return formatter.Format(w, styles.Fallback, iterator)
+}
+
+// WriteHighlightCSS 生成指定 chroma 主题（如 monokai / github）的代码高亮 css
+func WriteHighlightCSS(w io.Writer, styleName string) error {
+	style := styles.Get(styleName)
+	if style == styles.Fallback && styleName != styles.Fallback.Name {
+		return errors.Errorf("unknown highlight style %s", styleName)
+	}
+	formatter := chromahtml.New(chromahtml.WithClasses(true), chromahtml.WithLineNumbers(true))
+	return formatter.WriteCSS(w, style)
+}
@@ -0,0 +1,246 @@
+package markdownx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+	"github.com/gomarkdown/markdown/html"
+)
+
+// mermaid 代码块的语言标识
+const langMermaid = "mermaid"
+
+// nodeHook 按节点类型为渲染出的 html 标签添加 css 类
+//
+// 段落，标题，列表等节点，默认渲染器会输出节点上的 Attribute，只需要设置 Attribute 后交给默认渲染器处理；
+// 其余节点（行内代码，代码块，列表项，表格行 / 单元格）不支持 Attribute，需要自行输出
+type nodeHook struct {
+	renderer        *html.Renderer
+	classMap        ClassMap
+	imageResolver   ImageResolver
+	admonitionIcons map[AdmonitionKind]string
+	// 脚注被引用的次数（脚注 slug -> 次数）
+	footnoteRefs map[string]int
+}
+
+// render 实现 html.RenderNodeFunc，返回值 handled 为 false 表示交给默认渲染器处理
+func (h *nodeHook) render(w io.Writer, node ast.Node, entering bool) (ast.WalkStatus, bool) {
+	switch n := node.(type) {
+	case *ast.Paragraph:
+		// 只包含一张带标题的图片的段落，输出为 figure
+		if img := figureImage(n); img != nil {
+			h.renderFigure(w, img, entering)
+			return ast.GoToNext, true
+		}
+		h.setClass(entering, &n.Container, "p")
+	case *ast.Heading:
+		h.setClass(entering, &n.Container, fmt.Sprintf("h%d", n.Level))
+		// 标题 ID 在进入节点时才会去重，因此在离开节点时添加锚点
+		if !entering && n.HeadingID != "" {
+			h.renderHeadingAnchor(w, n.HeadingID)
+		}
+	case *ast.List:
+		if n.IsFootnotesList {
+			h.renderFootnotes(w, n, entering)
+			return ast.GoToNext, true
+		}
+		h.setClass(entering, &n.Container, listTag(n))
+	case *ast.BlockQuote:
+		h.setClass(entering, &n.Container, "blockquote")
+	case *Admonition:
+		h.renderAdmonition(w, n, entering)
+		return ast.GoToNext, true
+	case *ast.Table:
+		h.setClass(entering, &n.Container, "table")
+	case *ast.Image:
+		// 图片的 alt 属性取自子节点的文本，因此在进入节点时一并输出，跳过子节点
+		if entering {
+			h.setClass(entering, &n.Container, "img")
+			h.renderImage(w, n)
+			return ast.SkipChildren, true
+		}
+		return ast.GoToNext, true
+	case *ast.Link:
+		if n.NoteID != 0 {
+			if entering {
+				h.renderFootnoteRef(w, n)
+			}
+			return ast.SkipChildren, true
+		}
+		if class := h.classMap["a"]; entering && class != "" {
+			n.AdditionalAttributes = append(n.AdditionalAttributes, classAttr(class))
+		}
+	case *ast.Code:
+		h.renderer.OutTag(w, "<code", h.classAttrs("code"))
+		html.EscapeHTML(w, n.Literal)
+		h.renderer.Outs(w, "</code>")
+		return ast.GoToNext, true
+	case *ast.CodeBlock:
+		h.renderCodeBlock(w, n)
+		return ast.GoToNext, true
+	case *ast.MathBlock:
+		// 与默认渲染器一致，但前后换行（默认渲染器的输出会与下一个段落粘连），且段落同样使用 p 的样式
+		if entering {
+			h.renderer.CR(w)
+			h.renderer.OutTag(w, "<p", h.classAttrs("p"))
+			h.renderer.Outs(w, `<span class="math display">\[`)
+			html.EscapeHTML(w, n.Literal)
+		} else {
+			h.renderer.Outs(w, `\]</span></p>`)
+			h.renderer.CR(w)
+		}
+		return ast.GoToNext, true
+	case *ast.ListItem:
+		if n.RefLink != nil {
+			h.renderFootnote(w, n, entering)
+			return ast.GoToNext, true
+		}
+		return ast.GoToNext, h.renderListItem(w, n, entering)
+	case *ast.TableRow:
+		if entering {
+			h.renderer.CR(w)
+			h.renderer.OutTag(w, "<tr", h.classAttrs("tr"))
+		} else {
+			h.renderer.Outs(w, "</tr>")
+			h.renderer.CR(w)
+		}
+		return ast.GoToNext, true
+	case *ast.TableCell:
+		h.renderTableCell(w, n, entering)
+		return ast.GoToNext, true
+	}
+	return ast.GoToNext, false
+}
+
+// setClass 为支持 Attribute 的节点设置 css 类（只在进入节点时设置一次）
+func (h *nodeHook) setClass(entering bool, container *ast.Container, tag string) {
+	class := h.classMap[tag]
+	if !entering || class == "" {
+		return
+	}
+	if container.Attribute == nil {
+		container.Attribute = &ast.Attribute{}
+	}
+	container.Attribute.Classes = append(container.Attribute.Classes, []byte(class))
+}
+
+// classAttrs 获取标签对应的 class 属性，不存在则返回 nil
+func (h *nodeHook) classAttrs(tag string) []string {
+	if class := h.classMap[tag]; class != "" {
+		return []string{classAttr(class)}
+	}
+	return nil
+}
+
+// renderHeadingAnchor 输出标题锚点（悬停标题时展示，点击可复制链接）
+func (h *nodeHook) renderHeadingAnchor(w io.Writer, id string) {
+	class := strings.TrimSpace(HeadingAnchorClass + " " + h.classMap[ClassMapKeyHeadingAnchor])
+	h.renderer.Outs(w, `<a `+classAttr(class)+` href="#`)
+	html.EscapeHTML(w, []byte(id))
+	h.renderer.Outs(w, `" aria-label="复制链接">#</a>`)
+}
+
+// renderCodeBlock 输出代码块，代码块信息中带有标题时，使用 figure 包裹并添加 figcaption
+func (h *nodeHook) renderCodeBlock(w io.Writer, codeBlock *ast.CodeBlock) {
+	fi := parseFenceInfo(string(codeBlock.Info))
+
+	h.renderer.CR(w)
+	if fi.title != "" {
+		h.renderer.Outs(w, "<figure>")
+		h.renderer.OutTag(w, "<figcaption", h.classAttrs(ClassMapKeyCodeTitle))
+		html.EscapeHTML(w, []byte(fi.title))
+		h.renderer.Outs(w, "</figcaption>")
+	}
+	h.renderCode(w, codeBlock.Literal, fi)
+	if fi.title != "" {
+		h.renderer.Outs(w, "</figure>")
+	}
+	if !html.IsListItem(codeBlock.Parent) {
+		h.renderer.CR(w)
+	}
+}
+
+// renderCode 输出代码块中的代码：
+// - mermaid 代码块需要是 <code class="mermaid"> 才能被 mermaid.js 识别（默认渲染器会输出 language-mermaid）
+// - 没有语言标识（也没有行号 / 高亮行）的 code 标签与行内代码一致
+// - 其余代码块使用 chroma 高亮，高亮失败则退化为 language-xxx + 代码块的 css 类
+func (h *nodeHook) renderCode(w io.Writer, code []byte, fi fenceInfo) {
+	var attrs []string
+	switch {
+	case fi.lang == langMermaid:
+		attrs = []string{classAttr(langMermaid)}
+	case fi.lang == "" && !fi.lineNumbers && len(fi.highlightLines) == 0:
+		attrs = h.classAttrs("code")
+	default:
+		var buf bytes.Buffer
+		if err := h.highlight(&buf, string(code), fi); err == nil {
+			h.renderer.Out(w, buf.Bytes())
+			return
+		}
+		attrs = []string{classAttr(strings.TrimSpace("language-" + fi.lang + " " + h.classMap[ClassMapKeyCodeBlock]))}
+	}
+
+	h.renderer.OutTag(w, "<pre", h.classAttrs("pre"))
+	h.renderer.OutTag(w, "<code", attrs)
+	html.EscapeHTML(w, code)
+	h.renderer.Outs(w, "</code></pre>")
+}
+
+// renderListItem 输出普通列表项，定义列表交给默认渲染器处理
+func (h *nodeHook) renderListItem(w io.Writer, listItem *ast.ListItem, entering bool) bool {
+	if listItem.ListFlags&(ast.ListTypeDefinition|ast.ListTypeTerm) != 0 {
+		return false
+	}
+	if !entering {
+		h.renderer.Outs(w, "</li>")
+		h.renderer.CR(w)
+		return true
+	}
+	if html.ListItemOpenCR(listItem) {
+		h.renderer.CR(w)
+	}
+	h.renderer.OutTag(w, "<li", h.classAttrs("li"))
+	return true
+}
+
+// renderTableCell 输出表格单元格（与默认渲染器一致，额外添加 css 类）
+func (h *nodeHook) renderTableCell(w io.Writer, tableCell *ast.TableCell, entering bool) {
+	tag := "td"
+	if tableCell.IsHeader {
+		tag = "th"
+	}
+	if !entering {
+		h.renderer.Outs(w, "</"+tag+">")
+		h.renderer.CR(w)
+		return
+	}
+
+	attrs := h.classAttrs(tag)
+	if align := tableCell.Align.String(); align != "" {
+		attrs = append(attrs, fmt.Sprintf(`align="%s"`, align))
+	}
+	if tableCell.ColSpan > 0 {
+		attrs = append(attrs, fmt.Sprintf(`colspan="%d"`, tableCell.ColSpan))
+	}
+	if ast.GetPrevNode(tableCell) == nil {
+		h.renderer.CR(w)
+	}
+	h.renderer.OutTag(w, "<"+tag, attrs)
+}
+
+func listTag(list *ast.List) string {
+	switch {
+	case list.ListFlags&ast.ListTypeDefinition != 0:
+		return "dl"
+	case list.ListFlags&ast.ListTypeOrdered != 0:
+		return "ol"
+	}
+	return "ul"
+}
+
+func classAttr(class string) string {
+	return `class="` + escapeAttr(class) + `"`
+}
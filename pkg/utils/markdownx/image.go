@@ -0,0 +1,125 @@
+package markdownx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+	"github.com/gomarkdown/markdown/html"
+)
+
+// ImageSizes 响应式图片的 sizes 属性（宽屏时正文约占视口的 2/3，窄屏时占满）
+const ImageSizes = "(min-width: 1024px) 66vw, 100vw"
+
+// ImageSource 图片的一个尺寸版本
+type ImageSource struct {
+	URL   string
+	Width int
+}
+
+// ImageInfo 图片信息，用于输出响应式图片（srcset / sizes / width / height）
+type ImageInfo struct {
+	// Width / Height 原图尺寸
+	Width  int
+	Height int
+	// SrcSet 与原图格式相同的各尺寸版本（按宽度升序）
+	SrcSet []ImageSource
+	// WebPSrcSet WebP 格式的各尺寸版本，为空表示没有 WebP 版本
+	WebPSrcSet []ImageSource
+}
+
+// ImageResolver 根据图片地址获取图片信息，返回 nil 表示不做处理（如站外图片，图片不存在等）
+type ImageResolver func(src string) *ImageInfo
+
+// renderImage 输出图片：懒加载，异步解码，有图片信息时输出尺寸及 srcset（有 WebP 版本时使用 <picture>）
+func (h *nodeHook) renderImage(w io.Writer, img *ast.Image) {
+	var info *ImageInfo
+	if h.imageResolver != nil {
+		info = h.imageResolver(string(img.Destination))
+	}
+
+	attrs := html.BlockAttrs(img)
+	attrs = append(attrs, `src="`+escapeAttr(string(img.Destination))+`"`)
+	if info != nil {
+		if len(info.SrcSet) > 1 {
+			attrs = append(attrs, `srcset="`+srcSet(info.SrcSet)+`"`, `sizes="`+ImageSizes+`"`)
+		}
+		attrs = append(attrs, fmt.Sprintf(`width="%d" height="%d"`, info.Width, info.Height))
+	}
+	attrs = append(attrs, `alt="`+escapeAttr(plainText(img))+`"`)
+	// 作为 figure 输出时，标题已在 figcaption 中展示
+	if len(img.Title) != 0 && figureImage(img.Parent) == nil {
+		attrs = append(attrs, `title="`+escapeAttr(string(img.Title))+`"`)
+	}
+	attrs = append(attrs, `loading="lazy"`, `decoding="async"`)
+
+	// 与默认渲染器一致，输出自闭合标签
+	tag := strings.TrimSuffix(html.TagWithAttributes("<img", attrs), ">") + " />"
+	if info == nil || len(info.WebPSrcSet) == 0 {
+		h.renderer.Outs(w, tag)
+		return
+	}
+	h.renderer.Outs(w, `<picture><source type="image/webp" srcset="`+srcSet(info.WebPSrcSet)+`" sizes="`+ImageSizes+`" />`)
+	h.renderer.Outs(w, tag)
+	h.renderer.Outs(w, "</picture>")
+}
+
+// figureImage 段落只包含一张带标题的图片时，返回该图片（输出为 figure + figcaption），否则返回 nil
+func figureImage(node ast.Node) *ast.Image {
+	paragraph, ok := node.(*ast.Paragraph)
+	if !ok {
+		return nil
+	}
+	var img *ast.Image
+	for _, child := range paragraph.Children {
+		switch n := child.(type) {
+		case *ast.Image:
+			if img != nil {
+				return nil
+			}
+			img = n
+		case *ast.Text:
+			// 图片前后的空白（解析器会在图片前插入空的文本节点）
+			if len(bytes.TrimSpace(n.Literal)) != 0 {
+				return nil
+			}
+		default:
+			return nil
+		}
+	}
+	if img == nil || len(img.Title) == 0 {
+		return nil
+	}
+	return img
+}
+
+// renderFigure 输出带标题的图片（图片本身由子节点输出）
+func (h *nodeHook) renderFigure(w io.Writer, img *ast.Image, entering bool) {
+	if entering {
+		h.renderer.CR(w)
+		h.renderer.OutTag(w, "<figure", h.classAttrs("figure"))
+		return
+	}
+	h.renderer.OutTag(w, "<figcaption", h.classAttrs("figcaption"))
+	html.EscapeHTML(w, img.Title)
+	h.renderer.Outs(w, "</figcaption></figure>")
+	h.renderer.CR(w)
+}
+
+// srcSet 生成 srcset 属性值，如 /a-480.png 480w, /a.png 1200w
+func srcSet(sources []ImageSource) string {
+	parts := make([]string, 0, len(sources))
+	for _, source := range sources {
+		parts = append(parts, fmt.Sprintf("%s %dw", escapeAttr(source.URL), source.Width))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// escapeAttr 转义 html 属性值
+func escapeAttr(s string) string {
+	var sb strings.Builder
+	html.EscapeHTML(&sb, []byte(s))
+	return sb.String()
+}
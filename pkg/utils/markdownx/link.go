@@ -0,0 +1,41 @@
+package markdownx
+
+import (
+	"html"
+	"regexp"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+// 原始 html（如短代码生成的 html）中 a 标签的 href 属性
+var htmlHrefRegex = regexp.MustCompile(`<a\s[^>]*?\bhref\s*=\s*["']([^"']*)["']`)
+
+// Links 获取文档中的链接地址（按出现顺序，不含脚注引用及图片），包括原始 html 中 a 标签的链接
+func Links(doc ast.Node) []string {
+	var links []string
+	ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+		switch n := node.(type) {
+		case *ast.Link:
+			if n.NoteID == 0 {
+				links = append(links, string(n.Destination))
+			}
+		case *ast.HTMLSpan:
+			links = append(links, htmlLinks(n.Literal)...)
+		case *ast.HTMLBlock:
+			links = append(links, htmlLinks(n.Literal)...)
+		}
+		return ast.GoToNext
+	})
+	return links
+}
+
+func htmlLinks(literal []byte) []string {
+	var links []string
+	for _, match := range htmlHrefRegex.FindAllSubmatch(literal, -1) {
+		links = append(links, html.UnescapeString(string(match[1])))
+	}
+	return links
+}
@@ -1,73 +1,77 @@
 package markdownx
 
 import (
-	"regexp"
-	"strings"
-
 	"github.com/gomarkdown/markdown"
+	"github.com/gomarkdown/markdown/ast"
 	"github.com/gomarkdown/markdown/html"
 	"github.com/gomarkdown/markdown/parser"
 )
 
+// 默认的渲染器（使用默认的 tailwind css 类映射表）
+var defaultRenderer = NewRenderer(DefaultClassMap)
+
+// ToHTML 使用默认的类映射表，将 markdown 转换为 html
 func ToHTML(content []byte) string {
-	extensions := parser.CommonExtensions | parser.AutoHeadingIDs | parser.NoEmptyLineBeforeBlock
-	p := parser.NewWithExtensions(extensions)
-	doc := p.Parse(content)
+	return defaultRenderer.ToHTML(content)
+}
 
-	htmlFlags := html.CommonFlags | html.HrefTargetBlank
-	opts := html.RendererOptions{Flags: htmlFlags}
-	renderer := html.NewRenderer(opts)
+// Render 使用默认的类映射表，将 markdown AST 渲染为 html
+func Render(doc ast.Node) string {
+	return defaultRenderer.Render(doc)
+}
 
-	return wrapTailwindClass(patchMermaidClass(string(markdown.Render(doc, renderer))))
+// Parse 解析 markdown 为 AST（需要在 AST 上做额外处理时使用，如生成目录，否则直接使用 ToHTML 即可）
+func Parse(content []byte) ast.Node {
+	extensions := parser.CommonExtensions | parser.AutoHeadingIDs | parser.NoEmptyLineBeforeBlock |
+		parser.MathJax | parser.Footnotes
+	doc := parser.NewWithExtensions(extensions).Parse(content)
+	parseAdmonitions(doc)
+	return doc
 }
 
-// gomarkdown 会把 mermaid 块转换成 <code class="language-mermaid">，这其实是不正确的，应该是 <code class="mermaid">
-func patchMermaidClass(htmlContent string) string {
-	return strings.ReplaceAll(htmlContent, "<code class=\"language-mermaid\">", "<code class=\"mermaid\">")
+// Renderer markdown 渲染器
+type Renderer struct {
+	classMap        ClassMap
+	imageResolver   ImageResolver
+	admonitionIcons map[AdmonitionKind]string
 }
 
-var FullMatchHtmlTagClassMap = map[string]string{
-	"p":   "my-2 mx-2",
-	"ol":  "pl-1 list-decimal list-inside",
-	"ul":  "pl-4 list-disc",
-	"li":  "ml-4 my-2",
-	"pre": "my-4",
-	// markdown 单行 code 效果（多行另外处理）
-	"code": "bg-gray-100 text-orange-600",
-	// 使用 left-padding + left-border + bg-color 实现 markdown 引用的效果 :D
-	"blockquote": "mt-2 pl-2 py-1 border-l-8 border-green-200 bg-green-100",
-	// 斑马表格：奇偶数行不同背景色
-	"table": "table-auto border-collapse border border-gray-500",
-	"tr":    "odd:bg-white even:bg-gray-100",
-	"th":    "border border-gray-500 px-4 py-2",
-	"td":    "border border-gray-500 px-4 py-2",
+// NewRenderer 创建渲染器，classMap 为 html 标签 -> css 类的映射表
+func NewRenderer(classMap ClassMap) *Renderer {
+	return &Renderer{classMap: classMap}
 }
 
-var PrefixMatchHtmlTagClassMap = map[string]string{
-	"h1":  "mt-6 mb-4 font-semibold text-3xl",
-	"h2":  "mt-6 mb-4 font-semibold text-2xl",
-	"h3":  "mt-6 mb-4 font-semibold text-xl",
-	"h4":  "mt-6 mb-4 font-semibold text-lg",
-	"h5":  "mt-6 mb-4 font-semibold text-base",
-	"h6":  "mt-6 mb-4 font-semibold text-base text-gray-600",
-	"img": "my-6",
-	"a":   "text-blue-500",
+// WithImageResolver 返回使用指定图片解析器的渲染器（用于输出响应式图片）
+func (r *Renderer) WithImageResolver(resolver ImageResolver) *Renderer {
+	renderer := *r
+	renderer.imageResolver = resolver
+	return &renderer
 }
 
-// 由于 code 标签本身自带 class="language-xxx"，因此不能直接替换，只能补充
-var codeTagAdditionalClass = "p-4 rounded-xl"
+// WithAdmonitionIcons 返回使用指定提示块图标（提示块类型 -> 图标 html，如 svg）的渲染器
+func (r *Renderer) WithAdmonitionIcons(icons map[AdmonitionKind]string) *Renderer {
+	renderer := *r
+	renderer.admonitionIcons = icons
+	return &renderer
+}
+
+// ToHTML 将 markdown 转换为 html
+func (r *Renderer) ToHTML(content []byte) string {
+	return r.Render(Parse(content))
+}
+
+// Render 将 markdown AST 渲染为 html（渲染过程中会对重复的标题 ID 去重，并回写到 AST 中）
+func (r *Renderer) Render(doc ast.Node) string {
+	htmlFlags := html.CommonFlags | html.HrefTargetBlank
+	renderer := html.NewRenderer(html.RendererOptions{Flags: htmlFlags})
+	// 钩子中需要复用 html.Renderer 的换行等输出逻辑，因此在创建后再设置
+	renderer.Opts.RenderNodeHook = (&nodeHook{
+		renderer:        renderer,
+		classMap:        r.classMap,
+		imageResolver:   r.imageResolver,
+		admonitionIcons: r.admonitionIcons,
+		footnoteRefs:    map[string]int{},
+	}).render
 
-// wrapTailwindClass 为 markdown 转换成的 html 中的标签添加 tailwind css 类
-func wrapTailwindClass(htmlContent string) string {
-	// 前缀匹配的情况
-	for tagName, class := range PrefixMatchHtmlTagClassMap {
-		htmlContent = strings.ReplaceAll(htmlContent, "<"+tagName, "<"+tagName+" class=\""+class+"\"")
-	}
-	// 完全匹配的情况
-	for tagName, class := range FullMatchHtmlTagClassMap {
-		htmlContent = strings.ReplaceAll(htmlContent, "<"+tagName+">", "<"+tagName+" class=\""+class+"\">")
-	}
-	// 对带有 language 标识的 code 标签特殊处理
-	re := regexp.MustCompile(`<code class="language-[a-zA-Z]+`)
-	return re.ReplaceAllString(htmlContent, "$0 "+codeTagAdditionalClass)
+	return string(markdown.Render(doc, renderer))
 }
@@ -0,0 +1,211 @@
+package markdownx_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/utils/markdownx"
+)
+
+// 更新 golden 文件：go test ./pkg/utils/markdownx/... -update
+var update = flag.Bool("update", false, "update golden files")
+
+func TestToHTMLGolden(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.md"))
+	assert.Nil(t, err)
+	assert.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			content, err := os.ReadFile(file)
+			assert.Nil(t, err)
+
+			actual := markdownx.ToHTML(content)
+			goldenFile := strings.TrimSuffix(file, ".md") + ".golden.html"
+			if *update {
+				assert.Nil(t, os.WriteFile(goldenFile, []byte(actual), 0o644))
+			}
+
+			expected, err := os.ReadFile(goldenFile)
+			assert.Nil(t, err)
+			assert.Equal(t, string(expected), actual)
+		})
+	}
+}
+
+func TestToHTMLDeterministic(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "blocks.md"))
+	assert.Nil(t, err)
+
+	expected := markdownx.ToHTML(content)
+	for range 10 {
+		assert.Equal(t, expected, markdownx.ToHTML(content))
+	}
+}
+
+func TestCodeBlockLangEscaped(t *testing.T) {
+	for _, md := range []string{
+		"```x\" onmouseover=\"alert(1)\ncode\n```",
+		"```go\" onmouseover=\"alert(1)\npackage main\n```",
+	} {
+		out := markdownx.ToHTML([]byte(md))
+		assert.NotContains(t, out, `onmouseover="`, md)
+		assert.Contains(t, out, "<code class=\"", md)
+		assert.NotContains(t, out, `language-x"`, md)
+	}
+	// 合法的语言标识（含 + # . -）原样保留
+	assert.Contains(t, markdownx.ToHTML([]byte("```c++\nint a;\n```")), "language-c++")
+	assert.Contains(t, markdownx.ToHTML([]byte("```c#\nint a;\n```")), "language-c#")
+}
+
+func TestRendererCustomClassMap(t *testing.T) {
+	renderer := markdownx.NewRenderer(markdownx.ClassMap{"p": "prose", "code-block": "hl"})
+
+	assert.Equal(t, "<p class=\"prose\">text <code>code</code></p>\n", renderer.ToHTML([]byte("text `code`")))
+	assert.Contains(
+		t,
+		renderer.ToHTML([]byte("```go\npackage main\n```")),
+		"<pre><code class=\"language-go chroma hl\"><span class=\"line\">",
+	)
+	assert.Equal(
+		t,
+		"<h1 id=\"title\">Title<a class=\"heading-anchor\" href=\"#title\" aria-label=\"复制链接\">#</a></h1>\n",
+		renderer.ToHTML([]byte("# Title")),
+	)
+}
+
+func TestHeadings(t *testing.T) {
+	doc := markdownx.Parse([]byte("# 标题\n\n## `code` 与 **加粗**\n\n```md\n# 代码块中的标题\n```\n\n## 标题\n"))
+	html := markdownx.Render(doc)
+
+	expected := []markdownx.Heading{
+		{Level: 1, Text: "标题", ID: "标题"},
+		{Level: 2, Text: "code 与 加粗", ID: "code-与-加粗"},
+		// 重复的标题 ID 在渲染时去重
+		{Level: 2, Text: "标题", ID: "标题-1"},
+	}
+	assert.Equal(t, expected, markdownx.Headings(doc))
+	for _, heading := range expected {
+		assert.Contains(t, html, `id="`+heading.ID+`"`)
+	}
+}
+
+func TestText(t *testing.T) {
+	doc := markdownx.Parse([]byte(
+		"# 标题\n\n第一段 `inline` 与 [链接](/a)，\n换行。\n\n```go\nfmt.Println(\"ignored\")\n```\n\n" +
+			"- 列表 ![图片](/b.png)\n- 公式 $x^2$\n\n<div>html</div>\n",
+	))
+	assert.Equal(t, "标题\n第一段 inline 与 链接，\n换行。\n列表 \n\n公式 \n\n", markdownx.Text(doc))
+}
+
+func TestRendererImageResolver(t *testing.T) {
+	renderer := markdownx.NewRenderer(markdownx.ClassMap{}).WithImageResolver(func(src string) *markdownx.ImageInfo {
+		switch src {
+		case "/static/a.png":
+			return &markdownx.ImageInfo{
+				Width:  1200,
+				Height: 600,
+				SrcSet: []markdownx.ImageSource{{URL: "/images/480/a.png", Width: 480}, {URL: "/static/a.png", Width: 1200}},
+				WebPSrcSet: []markdownx.ImageSource{
+					{URL: "/images/480/a.png.webp", Width: 480}, {URL: "/images/1200/a.png.webp", Width: 1200},
+				},
+			}
+		case "/static/small.jpg":
+			return &markdownx.ImageInfo{Width: 320, Height: 240, SrcSet: []markdownx.ImageSource{{URL: src, Width: 320}}}
+		}
+		return nil
+	})
+
+	assert.Equal(
+		t,
+		`<p><picture><source type="image/webp" srcset="/images/480/a.png.webp 480w, /images/1200/a.png.webp 1200w" `+
+			`sizes="`+markdownx.ImageSizes+`" /><img src="/static/a.png" `+
+			`srcset="/images/480/a.png 480w, /static/a.png 1200w" sizes="`+markdownx.ImageSizes+`" `+
+			`width="1200" height="600" alt="图 &amp; 说明" loading="lazy" decoding="async" /></picture></p>`+"\n",
+		renderer.ToHTML([]byte(`![图 & 说明](/static/a.png)`)),
+	)
+	// 只有一个尺寸时不输出 srcset
+	assert.Equal(
+		t,
+		`<p><img src="/static/small.jpg" width="320" height="240" alt="小图" loading="lazy" decoding="async" /></p>`+"\n",
+		renderer.ToHTML([]byte(`![小图](/static/small.jpg)`)),
+	)
+	// 无法解析的图片（如站外图片）保持原样
+	assert.Equal(
+		t,
+		`<p><img src="https://example.com/a.png" alt="" loading="lazy" decoding="async" /></p>`+"\n",
+		renderer.ToHTML([]byte(`![](https://example.com/a.png)`)),
+	)
+}
+
+func TestRendererAdmonitionIcons(t *testing.T) {
+	renderer := markdownx.NewRenderer(markdownx.ClassMap{"blockquote": "quote"}).WithAdmonitionIcons(
+		map[markdownx.AdmonitionKind]string{markdownx.AdmonitionWarning: "<svg></svg>"},
+	)
+
+	// 类映射表中没有提示块的样式时，使用引用块的样式
+	assert.Equal(
+		t,
+		"<div class=\"quote\" role=\"note\">\n<p><svg></svg><span>警告</span></p>\n<p>内容</p>\n</div>\n",
+		renderer.ToHTML([]byte("> [!warning]\n> 内容")),
+	)
+	// 没有图标
+	assert.Equal(
+		t,
+		"<div class=\"quote\" role=\"note\">\n<p><span>提示</span></p>\n<p>内容</p>\n</div>\n",
+		renderer.ToHTML([]byte("> [!TIP]\n> 内容")),
+	)
+}
+
+func TestAdmonitionText(t *testing.T) {
+	doc := markdownx.Parse([]byte("> [!NOTE]\n> 说明\n\n> [!TIP] 不是标记\n"))
+	assert.Equal(t, "说明\n[!TIP] 不是标记\n", markdownx.Text(doc))
+}
+
+func TestFootnotes(t *testing.T) {
+	renderer := markdownx.NewRenderer(markdownx.ClassMap{})
+
+	assert.Equal(
+		t,
+		"<p>a<sup id=\"fnref:1\"><a href=\"#fn:1\" role=\"doc-noteref\">1</a></sup></p>\n\n"+
+			"<section role=\"doc-endnotes\">\n<hr>\n<ol>\n"+
+			"<li id=\"fn:1\">note <a href=\"#fnref:1\" role=\"doc-backlink\" aria-label=\"返回正文\">↩</a></li>\n"+
+			"</ol>\n</section>\n",
+		renderer.ToHTML([]byte("a[^1]\n\n[^1]: note\n")),
+	)
+	// 未被引用的脚注定义不输出
+	assert.NotContains(t, renderer.ToHTML([]byte("a\n\n[^1]: note\n")), "doc-endnotes")
+}
+
+func TestFigure(t *testing.T) {
+	renderer := markdownx.NewRenderer(markdownx.ClassMap{"figcaption": "caption"})
+
+	assert.Equal(
+		t,
+		"<figure><img src=\"/a.png\" alt=\"a &amp; b\" loading=\"lazy\" decoding=\"async\" />"+
+			"<figcaption class=\"caption\">标题 &lt;1&gt;</figcaption></figure>\n",
+		renderer.ToHTML([]byte(`![a & b](/a.png "标题 <1>")`)),
+	)
+	// 没有标题，或段落中还有其他内容时，不输出 figure
+	for _, content := range []string{`![a](/a.png)`, `![a](/a.png "标题") 文字`, `![a](/a.png "a") ![b](/b.png "b")`} {
+		assert.NotContains(t, renderer.ToHTML([]byte(content)), "<figure>", content)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	doc := markdownx.Parse([]byte(
+		"[a](/articles/a#简介) 与 https://example.com 及脚注[^1]，![图片](/b.png)\n\n" +
+			"`[c](/c)` <a class=\"x\" href=\"/articles/d?a=1&amp;b=2\">d</a>\n\n" +
+			"<div><a href='/articles/e'>e</a></div>\n\n[^1]: 脚注中的 [f](/f)\n",
+	))
+	assert.Equal(
+		t,
+		[]string{"/articles/a#简介", "https://example.com", "/articles/d?a=1&b=2", "/articles/e", "/f"},
+		markdownx.Links(doc),
+	)
+}
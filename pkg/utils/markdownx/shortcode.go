@@ -0,0 +1,294 @@
+package markdownx
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Shortcode 文章中的短代码，如 {{< article id="xxx" >}}，用于嵌入由程序生成的 html（文章链接，视频等）
+type Shortcode struct {
+	Name string
+	Args map[string]string
+	// Block 是否单独占一行（输出为独立的 html 块），块级 html（如 div）只能在此时输出，否则会被包裹在段落中
+	Block bool
+}
+
+// Arg 获取必填参数，不存在时返回错误
+func (sc Shortcode) Arg(key string) (string, error) {
+	value, ok := sc.Args[key]
+	if !ok || value == "" {
+		return "", errors.Errorf("missing required argument %q", key)
+	}
+	return value, nil
+}
+
+// ShortcodeHandler 短代码处理函数，返回替换短代码的 html
+type ShortcodeHandler func(sc Shortcode) (string, error)
+
+// Shortcodes 短代码名称 -> 处理函数
+type Shortcodes map[string]ShortcodeHandler
+
+var (
+	// 短代码，不支持跨行
+	shortcodeRegex = regexp.MustCompile(`\{\{<\s*(.*?)\s*>\}\}`)
+	// 短代码名称
+	shortcodeNameRegex = regexp.MustCompile(`^[a-zA-Z][\w-]*`)
+	// 短代码参数，值需要使用双引号（支持转义）或单引号包裹
+	shortcodeArgRegex = regexp.MustCompile(`^\s+([a-zA-Z][\w-]*)=("(?:[^"\\]|\\.)*"|'[^']*')`)
+	// 代码块的起止标记（缩进需要去除所在列表项的缩进后判断）
+	codeFenceRegex = regexp.MustCompile("^(`{3,}|~{3,})")
+	// 列表项标记及其后的空白
+	listMarkerRegex = regexp.MustCompile(`^([-*+]|\d{1,9}[.)])([ \t]+|$)`)
+	// 分隔线（如 * * *），不是列表项
+	thematicBreakRegex = regexp.MustCompile(`^(?:[-*_][ \t]*){3,}$`)
+	// 引用标记
+	blockquoteRegex = regexp.MustCompile(`^(?: {0,3}> ?)+`)
+)
+
+// 缩进代码块需要的缩进（相对于所在列表项的内容），也是每一级列表项内容的缩进
+const codeIndent = 4
+
+// ExpandShortcodes 将 markdown 中的短代码替换为处理函数生成的 html（代码块及行内代码中的短代码保持原样），
+// 单独占一行的短代码会作为独立的块（前后添加空行），file 仅用于错误信息，如 articles/xxx.md:12: unknown shortcode
+func ExpandShortcodes(file string, content []byte, shortcodes Shortcodes) ([]byte, error) {
+	lines := bytes.Split(content, []byte("\n"))
+	var scanner blockScanner
+	for idx, line := range lines {
+		if scanner.isCode(line) || !bytes.Contains(line, []byte("{{<")) {
+			continue
+		}
+
+		block := isShortcodeBlock(line)
+		expanded, err := expandLine(line, shortcodes, block)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s:%d", file, idx+1)
+		}
+		if block {
+			expanded = []byte("\n" + strings.TrimSpace(string(expanded)) + "\n")
+		}
+		lines[idx] = expanded
+	}
+	return bytes.Join(lines, []byte("\n")), nil
+}
+
+// blockScanner 逐行判断 markdown 中的代码块（围栏代码块及缩进代码块），规则与 gomarkdown 一致：
+// 列表项的内容（包括代码块）每一级需要缩进 4 个空格（或一个 tab），空行后缩进不足的内容及没有缩进的围栏代码块标记会结束列表；
+// 引用标记在判断前去除
+type blockScanner struct {
+	// 所在的各级列表项标记的缩进（相对于上一级列表项的内容）
+	items []int
+	// 当前所在围栏代码块的起始标记，为空表示不在围栏代码块中
+	fence []byte
+	// 围栏代码块所在列表的层级
+	fenceDepth int
+	// 上一行是否为空行
+	blank bool
+}
+
+// isCode 当前行是否属于代码块（包括围栏代码块的起止标记），需要按顺序传入每一行
+func (s *blockScanner) isCode(line []byte) bool {
+	line = blockquoteRegex.ReplaceAll(line, nil)
+	if len(bytes.TrimSpace(line)) == 0 {
+		s.blank = true
+		return s.fence != nil
+	}
+	blank := s.blank
+	s.blank = false
+
+	if s.fence != nil {
+		indent, text := splitIndent(stripIndent(line, s.fenceDepth))
+		marker := codeFenceRegex.Find(text)
+		if indent < codeIndent && marker != nil && marker[0] == s.fence[0] &&
+			len(marker) >= len(s.fence) && len(bytes.TrimSpace(text[len(marker):])) == 0 {
+			s.fence = nil
+		}
+		return true
+	}
+
+	// 逐级判断当前行属于哪一级列表项，并去除该级的缩进
+	depth := 0
+	for ; depth < len(s.items); depth++ {
+		indent, text := splitIndent(line)
+		isItem := isListItem(text)
+		// 同级的下一个列表项
+		if isItem && indent <= s.items[depth] {
+			break
+		}
+		// 不属于当前列表项
+		if !isItem && (blank && indent < codeIndent || indent == 0 && codeFenceRegex.Match(text)) {
+			break
+		}
+		line = stripIndent(line, 1)
+	}
+	s.items = s.items[:depth]
+
+	indent, text := splitIndent(line)
+	if indent >= codeIndent {
+		return true
+	}
+	// 列表项（可能有多级，如 - 1. xxx），内容可以是围栏代码块的起始标记
+	for isListItem(text) {
+		s.items = append(s.items, indent)
+		indent, text = splitIndent(text[len(listMarkerRegex.Find(text)):])
+	}
+	if marker := codeFenceRegex.Find(text); marker != nil {
+		s.fence, s.fenceDepth = marker, len(s.items)
+		return true
+	}
+	return false
+}
+
+// isListItem 是否为列表项（分隔线除外）
+func isListItem(text []byte) bool {
+	return listMarkerRegex.Match(text) && !thematicBreakRegex.Match(text)
+}
+
+// stripIndent 去除 n 级列表项的缩进，每级最多 4 个空格或一个 tab
+func stripIndent(line []byte, n int) []byte {
+	for ; n > 0 && len(line) != 0; n-- {
+		if line[0] == '\t' {
+			line = line[1:]
+			continue
+		}
+		for i := 0; i < codeIndent && len(line) != 0 && line[0] == ' '; i++ {
+			line = line[1:]
+		}
+	}
+	return line
+}
+
+// splitIndent 返回行首空白的宽度（tab 按 4 列对齐）及去除空白后的内容
+func splitIndent(line []byte) (int, []byte) {
+	width := 0
+	for i, c := range line {
+		switch c {
+		case ' ':
+			width++
+		case '\t':
+			width += codeIndent - width%codeIndent
+		default:
+			return width, line[i:]
+		}
+	}
+	return width, nil
+}
+
+// isShortcodeBlock 是否为单独占一行（没有缩进，即不在列表等结构中）的短代码
+func isShortcodeBlock(line []byte) bool {
+	if len(line) == 0 || line[0] == ' ' || line[0] == '\t' {
+		return false
+	}
+	return len(bytes.TrimSpace(shortcodeRegex.ReplaceAll(line, nil))) == 0
+}
+
+// expandLine 替换一行中（行内代码之外）的短代码
+func expandLine(line []byte, shortcodes Shortcodes, block bool) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, segment := range splitCodeSpans(line) {
+		if segment.code {
+			buf.Write(segment.text)
+			continue
+		}
+		var err error
+		buf.Write(shortcodeRegex.ReplaceAllFunc(segment.text, func(match []byte) []byte {
+			if err != nil {
+				return nil
+			}
+			var html string
+			html, err = expandShortcode(string(shortcodeRegex.FindSubmatch(match)[1]), shortcodes, block)
+			return []byte(html)
+		}))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// expandShortcode 解析短代码（名称及参数）并调用对应的处理函数
+func expandShortcode(body string, shortcodes Shortcodes, block bool) (string, error) {
+	name := shortcodeNameRegex.FindString(body)
+	if name == "" {
+		return "", errors.Errorf("invalid shortcode %q", body)
+	}
+	handler, ok := shortcodes[name]
+	if !ok {
+		return "", errors.Errorf("unknown shortcode %q", name)
+	}
+
+	sc := Shortcode{Name: name, Args: map[string]string{}, Block: block}
+	for rest := body[len(name):]; strings.TrimSpace(rest) != ""; {
+		arg := shortcodeArgRegex.FindStringSubmatch(rest)
+		if arg == nil {
+			return "", errors.Errorf("invalid arguments of shortcode %s: %q", name, strings.TrimSpace(rest))
+		}
+		value := arg[2][1 : len(arg[2])-1]
+		if arg[2][0] == '"' {
+			unquoted, err := strconv.Unquote(arg[2])
+			if err != nil {
+				return "", errors.Errorf("invalid argument %s of shortcode %s: %s", arg[1], name, arg[2])
+			}
+			value = unquoted
+		}
+		sc.Args[arg[1]] = value
+		rest = rest[len(arg[0]):]
+	}
+
+	html, err := handler(sc)
+	if err != nil {
+		return "", errors.Wrapf(err, "shortcode %s", name)
+	}
+	return html, nil
+}
+
+// 一行文本中的片段，code 表示是否为行内代码（包括两侧的反引号）
+type lineSegment struct {
+	text []byte
+	code bool
+}
+
+// splitCodeSpans 将一行文本切分为行内代码及普通文本（没有配对的反引号按普通文本处理）
+func splitCodeSpans(line []byte) []lineSegment {
+	var segments []lineSegment
+	start := 0
+	for i := 0; i < len(line); {
+		if line[i] != '`' {
+			i++
+			continue
+		}
+		n := backtickRun(line, i)
+		end := -1
+		for j := i + n; j < len(line); {
+			if line[j] != '`' {
+				j++
+				continue
+			}
+			m := backtickRun(line, j)
+			if m == n {
+				end = j + m
+				break
+			}
+			j += m
+		}
+		if end == -1 {
+			i += n
+			continue
+		}
+		segments = append(segments, lineSegment{text: line[start:i]}, lineSegment{text: line[i:end], code: true})
+		start, i = end, end
+	}
+	return append(segments, lineSegment{text: line[start:]})
+}
+
+// backtickRun 从 i 开始连续的反引号数量
+func backtickRun(line []byte, i int) int {
+	n := 0
+	for i+n < len(line) && line[i+n] == '`' {
+		n++
+	}
+	return n
+}
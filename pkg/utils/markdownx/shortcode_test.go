@@ -0,0 +1,133 @@
+package markdownx_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/utils/markdownx"
+)
+
+var testShortcodes = markdownx.Shortcodes{
+	"echo": func(sc markdownx.Shortcode) (string, error) {
+		return fmt.Sprintf("<span>%s|%s</span>", sc.Args["a"], sc.Args["b"]), nil
+	},
+	"box": func(sc markdownx.Shortcode) (string, error) {
+		id, err := sc.Arg("id")
+		if err != nil {
+			return "", err
+		}
+		return `<div class="box">` + id + `</div>`, nil
+	},
+}
+
+func TestExpandShortcodes(t *testing.T) {
+	content := strings.Join([]string{
+		`行内 {{< echo a="x \"y\"" b='z' >}} 与 {{<echo>}}。`,
+		`{{< box id="1" >}}`,
+		"`{{< unknown >}}` 行内代码中不处理",
+		"```md",
+		`{{< unknown >}}`,
+		"```",
+		`- {{< box id="2" >}}`,
+	}, "\n")
+
+	expanded, err := markdownx.ExpandShortcodes("a.md", []byte(content), testShortcodes)
+	assert.Nil(t, err)
+	assert.Equal(t, strings.Join([]string{
+		`行内 <span>x "y"|z</span> 与 <span>|</span>。`,
+		"\n" + `<div class="box">1</div>` + "\n",
+		"`{{< unknown >}}` 行内代码中不处理",
+		"```md",
+		`{{< unknown >}}`,
+		"```",
+		`- <div class="box">2</div>`,
+	}, "\n"), string(expanded))
+
+	// 单独占一行的短代码输出为独立的 html 块
+	assert.Equal(
+		t,
+		"<p class=\"my-2 mx-2\">段落</p>\n\n<div class=\"box\">1</div>\n\n<p class=\"my-2 mx-2\">段落</p>\n",
+		markdownx.ToHTML(must(markdownx.ExpandShortcodes("a.md", []byte("段落\n{{< box id=\"1\" >}}\n段落"), testShortcodes))),
+	)
+}
+
+// 代码块（渲染后的 code 元素）中的短代码
+var codeShortcodeRegex = regexp.MustCompile(`<code[^>]*>(?:<span[^>]*>|\s)*\{\{&lt; unknown &gt;\}\}`)
+
+func TestExpandShortcodesInCodeBlocks(t *testing.T) {
+	for _, content := range []string{
+		// 列表项中缩进的围栏代码块
+		"1. 步骤\n\n    ```md\n    {{< unknown >}}\n    ```",
+		"- 列表\n  - 子列表\n\n        ~~~\n        {{< unknown >}}\n        ~~~",
+		"- ```\n  {{< unknown >}}\n  ```",
+		// 空行后缩进不足的围栏代码块不在列表中
+		"- 列表\n\n  ```\n  {{< unknown >}}\n  ```",
+		// 缩进代码块（可以打断段落）
+		"段落\n\n    {{< unknown >}}\n\n    {{< unknown >}}",
+		"段落\n    {{< unknown >}}",
+		"\t{{< unknown >}}",
+		// 列表项中的缩进代码块需要再缩进 4 个空格
+		"- 列表\n\n        {{< unknown >}}",
+		"- 列表\n  - 子列表\n\n            {{< unknown >}}",
+		// 分隔线不是列表项
+		"* * *\n\n    {{< unknown >}}",
+		// 引用中的代码块
+		"> ```\n> {{< unknown >}}\n> ```",
+	} {
+		expanded, err := markdownx.ExpandShortcodes("a.md", []byte(content+"\n\n{{< echo a=\"1\" >}}"), testShortcodes)
+		assert.Nil(t, err, content)
+		assert.Equal(t, content+"\n\n\n<span>1|</span>\n", string(expanded), content)
+		// 与渲染结果一致
+		assert.Regexp(t, codeShortcodeRegex, markdownx.ToHTML(expanded), content)
+	}
+
+	// 列表项的内容（缩进不足 4 个空格时为段落）
+	for content, expected := range map[string]string{
+		"- 列表\n\n    {{< echo a=\"1\" >}}":                "- 列表\n\n    <span>1|</span>",
+		"- 列表\n\n      {{< echo a=\"1\" >}}":              "- 列表\n\n      <span>1|</span>",
+		"- 列表\n  - {{< echo a=\"1\" >}}":                  "- 列表\n  - <span>1|</span>",
+		"- 列表\n  - 子列表\n\n          {{< echo a=\"1\" >}}": "- 列表\n  - 子列表\n\n          <span>1|</span>",
+	} {
+		expanded, err := markdownx.ExpandShortcodes("a.md", []byte(content), testShortcodes)
+		assert.Nil(t, err, content)
+		assert.Equal(t, expected, string(expanded), content)
+		assert.NotContains(t, markdownx.ToHTML([]byte(content)), "<code", content)
+	}
+}
+
+func TestExpandShortcodesBlock(t *testing.T) {
+	var blocks []bool
+	shortcodes := markdownx.Shortcodes{"block": func(sc markdownx.Shortcode) (string, error) {
+		blocks = append(blocks, sc.Block)
+		return "", nil
+	}}
+	_, err := markdownx.ExpandShortcodes("a.md", []byte("{{< block >}}\n行内 {{< block >}}\n- {{< block >}}"), shortcodes)
+	assert.Nil(t, err)
+	assert.Equal(t, []bool{true, false, false}, blocks)
+}
+
+func TestExpandShortcodesError(t *testing.T) {
+	for content, expected := range map[string]string{
+		"第一行\n\n{{< unknown >}}":    `a.md:3: unknown shortcode "unknown"`,
+		"{{< box >}}":               `a.md:1: shortcode box: missing required argument "id"`,
+		`{{< box id=1 >}}`:          `a.md:1: invalid arguments of shortcode box: "id=1"`,
+		`{{< box id="1\q" >}}`:      `a.md:1: invalid argument id of shortcode box: "1\q"`,
+		"`code` {{< - >}} `code`":   `a.md:1: invalid shortcode "-"`,
+		"```\n```\n{{< unknown >}}": `a.md:3: unknown shortcode "unknown"`,
+	} {
+		_, err := markdownx.ExpandShortcodes("a.md", []byte(content), testShortcodes)
+		assert.NotNil(t, err, content)
+		assert.Equal(t, expected, err.Error(), content)
+	}
+}
+
+func must(content []byte, err error) []byte {
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
@@ -0,0 +1,50 @@
+package markdownx
+
+import (
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+// Text 获取文档的正文纯文本（用于字数统计等），忽略代码块，图片，原始 html 及数学公式，各个块之间以换行分隔
+func Text(doc ast.Node) string {
+	var sb strings.Builder
+	ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+		switch n := node.(type) {
+		case *ast.CodeBlock, *ast.HTMLBlock, *ast.HTMLSpan, *ast.Math, *ast.MathBlock, *ast.Image:
+			return ast.SkipChildren
+		case *ast.Text:
+			sb.Write(n.Literal)
+		case *ast.Code:
+			sb.Write(n.Literal)
+		case *ast.Softbreak, *ast.Hardbreak:
+			sb.WriteByte('\n')
+		case *ast.Paragraph, *ast.Heading, *ast.ListItem, *ast.TableCell:
+			if !entering {
+				sb.WriteByte('\n')
+			}
+		}
+		return ast.GoToNext
+	})
+	return sb.String()
+}
+
+// plainText 获取节点中的纯文本（忽略加粗，链接等格式）
+func plainText(node ast.Node) string {
+	var sb strings.Builder
+	ast.WalkFunc(node, func(n ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+		switch n := n.(type) {
+		case *ast.Text:
+			sb.Write(n.Literal)
+		case *ast.Code:
+			sb.Write(n.Literal)
+		case *ast.Math:
+			sb.Write(n.Literal)
+		}
+		return ast.GoToNext
+	})
+	return sb.String()
+}
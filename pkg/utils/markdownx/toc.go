@@ -0,0 +1,39 @@
+package markdownx
+
+import (
+	"strings"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+// Heading 文章中的标题
+type Heading struct {
+	// 标题等级（1 ~ 6）
+	Level int
+	// 标题文本（不含格式）
+	Text string
+	// 锚点 ID
+	ID string
+}
+
+// Headings 按出现顺序获取文档中所有带 ID 的标题
+//
+// 注：重复的标题 ID 在渲染时才会去重，因此需要在 Render 之后调用，才能与页面中的锚点一致
+func Headings(doc ast.Node) []Heading {
+	var headings []Heading
+	ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+		heading, ok := node.(*ast.Heading)
+		if !ok || !entering {
+			return ast.GoToNext
+		}
+		if heading.HeadingID != "" {
+			headings = append(headings, Heading{
+				Level: heading.Level,
+				Text:  strings.TrimSpace(plainText(heading)),
+				ID:    heading.HeadingID,
+			})
+		}
+		return ast.SkipChildren
+	})
+	return headings
+}
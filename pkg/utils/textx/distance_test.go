@@ -0,0 +1,27 @@
+package textx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/utils/textx"
+)
+
+func TestLevenshtein(t *testing.T) {
+	for _, c := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"scaling-in-k8s", "scaling-in-kubernetes", 8},
+		{"pipe-in-pyton", "pipe-in-python", 1},
+		{"内存泄漏", "内存泄露", 1},
+	} {
+		assert.Equal(t, c.expected, textx.Levenshtein(c.a, c.b), c.a+" / "+c.b)
+		assert.Equal(t, c.expected, textx.Levenshtein(c.b, c.a), c.b+" / "+c.a)
+	}
+}
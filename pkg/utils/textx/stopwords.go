@@ -0,0 +1,43 @@
+package textx
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// 停用词：常见的中文虚词 / 代词等组成的 bigram 及英文功能词，对区分文章主题没有帮助
+var stopwords = lo.SliceToMap(strings.Fields(`
+	一个 一些 一下 一样 一种 不是 不会 不能 也是 也可 了解 什么 他们 以及 以下 以上 但是 你们 其实 其他 具体 出现
+	可以 可能 各种 同时 因为 因此 如果 如何 实际 对于 已经 并且 应该 当然 我们 所以 所有 或者 无法 时候 是否
+	是一 最后 有一 有些 本文 然后 然而 由于 的一 的是 的时 的话 直接 相关 看到 知道 而且 自己 虽然 这个 这些 这是
+	这样 这种 这里 进行 通过 那么 那些 那个 部分 需要 首先 主要 比如 就是 还是 还有 其中 之后 之前 只是 只有 只要
+	a an and are as at be been but by can do does for from had has have how if in into is it its may more no not
+	of on or our should so some such than that the their them then there these they this to too use used using via
+	was we were what when where which while who will with would you your
+`), func(word string) (string, bool) { return word, true })
+
+// IsStopword 是否为停用词（分词结果中的 bigram / 小写单词）
+func IsStopword(token string) bool {
+	return stopwords[token]
+}
+
+// RemoveStopwords 去除分词结果中的停用词
+func RemoveStopwords(tokens []string) []string {
+	return lo.Reject(tokens, func(token string, _ int) bool { return IsStopword(token) })
+}
+
+// RemoveCommonTokens 去除在超过 maxDocRatio 比例的文档中都出现过的词（如 “开发” “代码”），
+// 这些词在文档间几乎没有区分度，却会让任意两篇文档都有一定的相似度
+func RemoveCommonTokens(docs [][]string, maxDocRatio float64) [][]string {
+	docFreq := map[string]int{}
+	for _, tokens := range docs {
+		for _, token := range lo.Uniq(tokens) {
+			docFreq[token]++
+		}
+	}
+	maxDocs := maxDocRatio * float64(len(docs))
+	return lo.Map(docs, func(tokens []string, _ int) []string {
+		return lo.Reject(tokens, func(token string, _ int) bool { return float64(docFreq[token]) > maxDocs })
+	})
+}
@@ -0,0 +1,73 @@
+// Package textx 文本统计工具（适用于中英文混排的内容）
+package textx
+
+import (
+	"math"
+	"unicode"
+)
+
+// Stats 文本统计结果
+type Stats struct {
+	// CJKChars 中日韩字符数（每个字符计为一个字）
+	CJKChars int
+	// LatinWords 拉丁文单词数（以空白字符 / 中日韩字符分隔）
+	LatinWords int
+	// Chars 字符数（不含空白字符）
+	Chars int
+}
+
+// Words 字数（中日韩字符数 + 拉丁文单词数）
+func (s Stats) Words() int {
+	return s.CJKChars + s.LatinWords
+}
+
+// ReadingMinutes 根据阅读速度（中日韩字符 / 拉丁文单词每分钟）估算阅读时间，
+// 单位为分钟，向上取整且至少为 1 分钟，没有内容时为 0
+func (s Stats) ReadingMinutes(cjkPerMinute, latinPerMinute int) int {
+	if s.Words() == 0 {
+		return 0
+	}
+	minutes := 0.0
+	if cjkPerMinute > 0 {
+		minutes += float64(s.CJKChars) / float64(cjkPerMinute)
+	}
+	if latinPerMinute > 0 {
+		minutes += float64(s.LatinWords) / float64(latinPerMinute)
+	}
+	return max(int(math.Ceil(minutes)), 1)
+}
+
+// Count 统计文本的字数与字符数：中日韩字符逐个计数，拉丁文按单词计数
+// （连续的非空白、非中日韩字符，且至少包含一个字母或数字，如 pprof, / v1.2 / don't）
+func Count(text string) Stats {
+	var stats Stats
+	inWord, wordHasLetter := false, false
+	endWord := func() {
+		if inWord && wordHasLetter {
+			stats.LatinWords++
+		}
+		inWord, wordHasLetter = false, false
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			endWord()
+			continue
+		case IsCJK(r):
+			endWord()
+			stats.CJKChars++
+		default:
+			inWord = true
+			wordHasLetter = wordHasLetter || unicode.IsLetter(r) || unicode.IsDigit(r)
+		}
+		stats.Chars++
+	}
+	endWord()
+	return stats
+}
+
+// IsCJK 是否为中日韩文字（不含标点符号）
+func IsCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
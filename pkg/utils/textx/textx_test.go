@@ -0,0 +1,92 @@
+package textx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/narasux/goblog/pkg/utils/textx"
+)
+
+func TestCount(t *testing.T) {
+	cases := []struct {
+		name     string
+		text     string
+		expected textx.Stats
+	}{
+		{name: "empty", text: "", expected: textx.Stats{}},
+		{name: "only spaces", text: " \n\t ", expected: textx.Stats{}},
+		{name: "chinese", text: "内存泄漏", expected: textx.Stats{CJKChars: 4, Chars: 4}},
+		{name: "english", text: "Hello, world! don't  panic", expected: textx.Stats{LatinWords: 4, Chars: 22}},
+		{
+			name:     "mixed without spaces",
+			text:     "使用pprof排查Go内存泄漏",
+			expected: textx.Stats{CJKChars: 8, LatinWords: 2, Chars: 15},
+		},
+		{
+			name:     "punctuation is not a word",
+			text:     "排查 —— 结论：v1.2 版本修复。",
+			expected: textx.Stats{CJKChars: 8, LatinWords: 1, Chars: 16},
+		},
+		{name: "japanese and korean", text: "ひらがな カタカナ 한국어", expected: textx.Stats{CJKChars: 11, Chars: 11}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, textx.Count(c.text))
+		})
+	}
+}
+
+func TestStatsReadingMinutes(t *testing.T) {
+	assert.Equal(t, 0, textx.Stats{}.ReadingMinutes(300, 200))
+	// 不足一分钟按一分钟计
+	assert.Equal(t, 1, textx.Stats{CJKChars: 10}.ReadingMinutes(300, 200))
+	assert.Equal(t, 2, textx.Stats{CJKChars: 600}.ReadingMinutes(300, 200))
+	// 中英文分别按各自的速度计算后累加，向上取整
+	assert.Equal(t, 3, textx.Stats{CJKChars: 450, LatinWords: 150}.ReadingMinutes(300, 200))
+	assert.Equal(t, 9, textx.Stats{CJKChars: 600, LatinWords: 1400}.ReadingMinutes(300, 200))
+	// 速度配置不合法时忽略对应部分
+	assert.Equal(t, 1, textx.Stats{CJKChars: 600}.ReadingMinutes(0, 200))
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"内存", "存泄", "泄漏", "pprof", "go", "排查"}, textx.Tokenize("内存泄漏：pprof、Go 排查"))
+	// 单个汉字 / 字母不成词
+	assert.Equal(t, []string{"v1"}, textx.Tokenize("是 a V1"))
+	assert.Empty(t, textx.Tokenize(""))
+}
+
+func TestTFIDFCosine(t *testing.T) {
+	docs := [][]string{
+		textx.Tokenize("Kubernetes 集群扩缩容"),
+		textx.Tokenize("Kubernetes 集群共享"),
+		textx.Tokenize("鲜肉馄饨的做法"),
+	}
+	vectors := textx.TFIDF(docs)
+
+	assert.InDelta(t, 1, textx.Cosine(vectors[0], vectors[0]), 1e-9)
+	assert.Greater(t, textx.Cosine(vectors[0], vectors[1]), textx.Cosine(vectors[0], vectors[2]))
+	assert.Equal(t, 0.0, textx.Cosine(vectors[0], vectors[2]))
+	// 相似度是对称的
+	assert.InDelta(t, textx.Cosine(vectors[0], vectors[1]), textx.Cosine(vectors[1], vectors[0]), 1e-9)
+}
+
+func TestRemoveStopwords(t *testing.T) {
+	assert.Equal(t, []string{"内存", "泄漏", "pprof"}, textx.RemoveStopwords([]string{"我们", "内存", "泄漏", "the", "pprof"}))
+	assert.Empty(t, textx.RemoveStopwords(textx.Tokenize("我们，可以 the")))
+}
+
+func TestRemoveCommonTokens(t *testing.T) {
+	docs := [][]string{
+		{"kubernetes", "集群", "开发", "开发"},
+		{"kubernetes", "共享", "开发"},
+		{"馄饨", "开发"},
+	}
+	// 开发出现在所有文档中，kubernetes 只出现在 2/3 的文档中
+	assert.Equal(t, [][]string{
+		{"kubernetes", "集群"},
+		{"kubernetes", "共享"},
+		{"馄饨"},
+	}, textx.RemoveCommonTokens(docs, 0.7))
+}
@@ -0,0 +1,92 @@
+package textx
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Tokenize 分词：中日韩文字按相邻两字切分（bigram，无需词典），拉丁文按单词切分并转为小写，忽略单个字母及标点符号
+func Tokenize(text string) []string {
+	var tokens []string
+	var word strings.Builder
+	var prevCJK rune
+	flushWord := func() {
+		if word.Len() > 1 {
+			tokens = append(tokens, strings.ToLower(word.String()))
+		}
+		word.Reset()
+	}
+
+	for _, r := range text {
+		switch {
+		case IsCJK(r):
+			flushWord()
+			if prevCJK != 0 {
+				tokens = append(tokens, string([]rune{prevCJK, r}))
+			}
+			prevCJK = r
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		default:
+			flushWord()
+		}
+		prevCJK = 0
+	}
+	flushWord()
+	return tokens
+}
+
+// Vector 稀疏向量（词 -> 权重）
+type Vector map[string]float64
+
+// TFIDF 计算各文档（已分词）的 TF-IDF 向量，向量已归一化，可直接通过点积计算余弦相似度
+func TFIDF(docs [][]string) []Vector {
+	// 包含各个词的文档数
+	docFreq := map[string]int{}
+	for _, tokens := range docs {
+		seen := map[string]bool{}
+		for _, token := range tokens {
+			if !seen[token] {
+				seen[token] = true
+				docFreq[token]++
+			}
+		}
+	}
+
+	vectors := make([]Vector, len(docs))
+	for idx, tokens := range docs {
+		termFreq := map[string]int{}
+		for _, token := range tokens {
+			termFreq[token]++
+		}
+
+		vector, norm := Vector{}, 0.0
+		for token, freq := range termFreq {
+			// 平滑的 IDF，避免所有文档都包含的词权重为 0 时向量为空
+			idf := math.Log(float64(1+len(docs))/float64(1+docFreq[token])) + 1
+			weight := float64(freq) / float64(len(tokens)) * idf
+			vector[token] = weight
+			norm += weight * weight
+		}
+		norm = math.Sqrt(norm)
+		for token := range vector {
+			vector[token] /= norm
+		}
+		vectors[idx] = vector
+	}
+	return vectors
+}
+
+// Cosine 计算两个归一化向量的余弦相似度
+func Cosine(a, b Vector) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	similarity := 0.0
+	for token, weight := range a {
+		similarity += weight * b[token]
+	}
+	return similarity
+}